@@ -21,7 +21,7 @@ var (
 	BSCTestNetworkRPCFlag = &cli.StringFlag{
 		Name:  "testnet",
 		Value: "https://data-seed-prebsc-1-s1.binance.org:8545/",
-		Usage: "the rpc endpoint of bsc testnet",
+		Usage: "the comma separated rpc endpoints of bsc testnet",
 	}
 	LocalTestNetworkRPCFlag = &cli.StringFlag{
 		Name:  "local",
@@ -41,4 +41,29 @@ var (
 		Value: 1000,
 		Usage: "batch size for reading history record from the database",
 	}
+	VerifierFlag = &cli.StringFlag{
+		Name:  "verifier",
+		Usage: "the verifier contract updated by verifier_parse.py",
+	}
+	OutputFlag = &cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Usage:   "the output file",
+	}
+	FromFlag = &cli.Int64Flag{
+		Name:  "from",
+		Usage: "the first l1 block height",
+	}
+	ToFlag = &cli.Int64Flag{
+		Name:  "to",
+		Usage: "the last l1 block height",
+	}
+	RepairFlag = &cli.BoolFlag{
+		Name:  "repair",
+		Usage: "repair the discrepancies which can be repaired safely, the monitor should be stopped",
+	}
+	AssetIdFlag = &cli.Int64Flag{
+		Name:  "asset",
+		Usage: "asset id",
+	}
 )
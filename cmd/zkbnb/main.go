@@ -10,12 +10,15 @@ import (
 	"github.com/bnb-chain/zkbnb/cmd/flags"
 	"github.com/bnb-chain/zkbnb/service/apiserver"
 	"github.com/bnb-chain/zkbnb/service/committer"
+	"github.com/bnb-chain/zkbnb/service/coordinator"
 	"github.com/bnb-chain/zkbnb/service/monitor"
 	"github.com/bnb-chain/zkbnb/service/prover"
 	"github.com/bnb-chain/zkbnb/service/sender"
 	"github.com/bnb-chain/zkbnb/service/witness"
 	"github.com/bnb-chain/zkbnb/tools/dbinitializer"
+	"github.com/bnb-chain/zkbnb/tools/keymanager"
 	"github.com/bnb-chain/zkbnb/tools/recovery"
+	"github.com/bnb-chain/zkbnb/tools/witnessmigration"
 )
 
 // Build Info (set via linker flags)
@@ -56,6 +59,20 @@ func main() {
 					return prover.Run(cCtx.String(flags.ConfigFlag.Name))
 				},
 			},
+			{
+				Name:  "coordinator",
+				Usage: "Run prover coordinator service",
+				Flags: []cli.Flag{
+					flags.ConfigFlag,
+				},
+				Action: func(cCtx *cli.Context) error {
+					if !cCtx.IsSet(flags.ConfigFlag.Name) {
+						return cli.ShowSubcommandHelp(cCtx)
+					}
+
+					return coordinator.Run(cCtx.String(flags.ConfigFlag.Name))
+				},
+			},
 			{
 				Name:  "witness",
 				Usage: "Run witness service",
@@ -69,6 +86,45 @@ func main() {
 
 					return witness.Run(cCtx.String(flags.ConfigFlag.Name))
 				},
+				Subcommands: []*cli.Command{
+					{
+						Name:  "migrate",
+						Usage: "Migrate the json witnesses to the compressed encoding and the witness storage",
+						Flags: []cli.Flag{
+							flags.ConfigFlag,
+							flags.BatchSizeFlag,
+						},
+						Action: func(cCtx *cli.Context) error {
+							if !cCtx.IsSet(flags.ConfigFlag.Name) {
+								return cli.ShowSubcommandHelp(cCtx)
+							}
+
+							return witnessmigration.MigrateWitnesses(
+								cCtx.String(flags.ConfigFlag.Name),
+								cCtx.Int(flags.BatchSizeFlag.Name),
+							)
+						},
+					},
+					{
+						Name:  "check",
+						Usage: "Check the witness of a block against the circuit constraints without proving it",
+						Flags: []cli.Flag{
+							flags.ConfigFlag,
+							flags.BlockHeightFlag,
+						},
+						Action: func(cCtx *cli.Context) error {
+							if !cCtx.IsSet(flags.ConfigFlag.Name) ||
+								!cCtx.IsSet(flags.BlockHeightFlag.Name) {
+								return cli.ShowSubcommandHelp(cCtx)
+							}
+
+							return witness.Check(
+								cCtx.String(flags.ConfigFlag.Name),
+								cCtx.Int64(flags.BlockHeightFlag.Name),
+							)
+						},
+					},
+				},
 			},
 			{
 				Name:  "monitor",
@@ -83,6 +139,51 @@ func main() {
 
 					return monitor.Run(cCtx.String(flags.ConfigFlag.Name))
 				},
+				Subcommands: []*cli.Command{
+					{
+						Name:  "resync",
+						Usage: "Compare the synced l1 blocks with the logs on L1 and repair the discrepancies",
+						Flags: []cli.Flag{
+							flags.ConfigFlag,
+							flags.FromFlag,
+							flags.ToFlag,
+							flags.RepairFlag,
+						},
+						Action: func(cCtx *cli.Context) error {
+							if !cCtx.IsSet(flags.ConfigFlag.Name) ||
+								!cCtx.IsSet(flags.FromFlag.Name) ||
+								!cCtx.IsSet(flags.ToFlag.Name) {
+								return cli.ShowSubcommandHelp(cCtx)
+							}
+
+							return monitor.Resync(
+								cCtx.String(flags.ConfigFlag.Name),
+								cCtx.Int64(flags.FromFlag.Name),
+								cCtx.Int64(flags.ToFlag.Name),
+								cCtx.Bool(flags.RepairFlag.Name),
+							)
+						},
+					},
+					{
+						Name:  "refresh-asset",
+						Usage: "Refresh the name, symbol and decimals of an asset from its ERC20 contract",
+						Flags: []cli.Flag{
+							flags.ConfigFlag,
+							flags.AssetIdFlag,
+						},
+						Action: func(cCtx *cli.Context) error {
+							if !cCtx.IsSet(flags.ConfigFlag.Name) ||
+								!cCtx.IsSet(flags.AssetIdFlag.Name) {
+								return cli.ShowSubcommandHelp(cCtx)
+							}
+
+							return monitor.RefreshAsset(
+								cCtx.String(flags.ConfigFlag.Name),
+								cCtx.Int64(flags.AssetIdFlag.Name),
+							)
+						},
+					},
+				},
 			},
 			{
 				Name: "committer",
@@ -154,6 +255,65 @@ func main() {
 							)
 						},
 					},
+					{
+						Name:  "migrate",
+						Usage: "Migrate DB tables to the current schema",
+						Flags: []cli.Flag{
+							flags.DSNFlag,
+						},
+						Action: func(cCtx *cli.Context) error {
+							if !cCtx.IsSet(flags.DSNFlag.Name) {
+								return cli.ShowSubcommandHelp(cCtx)
+							}
+
+							return dbinitializer.Migrate(cCtx.String(flags.DSNFlag.Name))
+						},
+					},
+				},
+			},
+			{
+				Name:  "keys",
+				Usage: "Proving and verifying keys tools",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "manifest",
+						Usage: "Generate the manifest of the keys configured for the prover",
+						Flags: []cli.Flag{
+							flags.ConfigFlag,
+							flags.OutputFlag,
+							flags.VerifierFlag,
+						},
+						Action: func(cCtx *cli.Context) error {
+							if !cCtx.IsSet(flags.ConfigFlag.Name) ||
+								!cCtx.IsSet(flags.OutputFlag.Name) {
+								return cli.ShowSubcommandHelp(cCtx)
+							}
+
+							return keymanager.GenerateManifest(
+								cCtx.String(flags.ConfigFlag.Name),
+								cCtx.String(flags.OutputFlag.Name),
+								cCtx.String(flags.VerifierFlag.Name),
+							)
+						},
+					},
+					{
+						Name:  "verify",
+						Usage: "Verify the keys configured for the prover against the manifest",
+						Flags: []cli.Flag{
+							flags.ConfigFlag,
+							flags.VerifierFlag,
+						},
+						Action: func(cCtx *cli.Context) error {
+							if !cCtx.IsSet(flags.ConfigFlag.Name) {
+								return cli.ShowSubcommandHelp(cCtx)
+							}
+
+							return keymanager.VerifyKeys(
+								cCtx.String(flags.ConfigFlag.Name),
+								cCtx.String(flags.VerifierFlag.Name),
+							)
+						},
+					},
 				},
 			},
 			{
@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/bnb-chain/zkbnb/common"
 	"github.com/bnb-chain/zkbnb/types"
 )
 
@@ -81,3 +82,25 @@ func TestComputeOutputPrice(t *testing.T) {
 	)
 	assert.Equal(t, deltaY.Int64(), int64(1004))
 }
+
+func TestComputeEmptyLpAmount(t *testing.T) {
+	amounts := []string{
+		"1",
+		"1000",
+		"99999999999",
+		"1000000000000000000",
+		"123456789012345678901234567",
+		"340282366920938463463374607431768211455",
+	}
+	for _, a := range amounts {
+		for _, b := range amounts {
+			assetAAmount, _ := new(big.Int).SetString(a, 10)
+			assetBAmount, _ := new(big.Int).SetString(b, 10)
+			lpAmount, err := ComputeEmptyLpAmount(assetAAmount, assetBAmount)
+			assert.NoError(t, err)
+			expected, err := common.CleanPackedAmount(new(big.Int).Sqrt(new(big.Int).Mul(assetAAmount, assetBAmount)))
+			assert.NoError(t, err)
+			assert.Equal(t, expected.String(), lpAmount.String(), "a=%s b=%s", a, b)
+		}
+	}
+}
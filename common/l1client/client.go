@@ -0,0 +1,444 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package l1client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb-eth-rpc/_rpc"
+)
+
+const (
+	// The endpoint which falls behind the highest head by more blocks is unhealthy.
+	maxHeadLag = 16
+
+	healthCheckTimeout = 5 * time.Second
+)
+
+type endpoint struct {
+	cli     *_rpc.ProviderClient
+	healthy bool
+}
+
+// Client is a L1 rpc client over several endpoints. The calls go to the active endpoint and fail over
+// to the other ones on errors, the healthy endpoints are tried before the unhealthy ones. With quorum
+// reads, the logs, head height and contract calls are read from two endpoints which have to agree.
+type Client struct {
+	endpoints []*endpoint
+	quorum    bool
+
+	mu     sync.RWMutex
+	active int
+}
+
+// ParseEndpoints splits the comma separated endpoints.
+func ParseEndpoints(value string) []string {
+	var urls []string
+	for _, url := range strings.Split(value, ",") {
+		url = strings.TrimSpace(url)
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+func NewClient(urls []string, quorum bool) (*Client, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no l1 rpc endpoint")
+	}
+	if quorum && len(urls) < 2 {
+		return nil, fmt.Errorf("quorum reads need at least 2 l1 rpc endpoints, got %d", len(urls))
+	}
+	c := &Client{quorum: quorum}
+	for _, url := range urls {
+		cli, err := _rpc.NewClient(url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial l1 rpc endpoint %d, err: %v", len(c.endpoints), err)
+		}
+		c.endpoints = append(c.endpoints, &endpoint{cli: cli, healthy: true})
+	}
+	return c, nil
+}
+
+// The contract bindings use the client as the caller and filterer, so that they fail over as well.
+var (
+	_ bind.ContractCaller   = (*Client)(nil)
+	_ bind.ContractFilterer = (*Client)(nil)
+)
+
+// Provider returns the client of the active endpoint, it doesn't fail over. The contract bindings should
+// be created on the client itself.
+func (c *Client) Provider() *_rpc.ProviderClient {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.endpoints[c.active].cli
+}
+
+// isEndpointError tells whether the error is caused by the endpoint rather than the request, the
+// errors returned by the node and the missing results are not.
+func isEndpointError(err error) bool {
+	if errors.Is(err, ethereum.NotFound) || errors.Is(err, _rpc.ErrInvalidHashValue) ||
+		errors.Is(err, _rpc.ErrInvalidAddress) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// candidates returns the endpoints in the order they are tried: the active one, the other healthy ones
+// and the unhealthy ones.
+func (c *Client) candidates() []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	indexes := []int{c.active}
+	for _, healthy := range []bool{true, false} {
+		for i, e := range c.endpoints {
+			if i != c.active && e.healthy == healthy {
+				indexes = append(indexes, i)
+			}
+		}
+	}
+	return indexes
+}
+
+func (c *Client) isHealthy(i int) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.endpoints[i].healthy
+}
+
+func (c *Client) markHealthy(i int, healthy bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.endpoints[i].healthy == healthy {
+		return
+	}
+	c.endpoints[i].healthy = healthy
+	if healthy {
+		logx.Infof("l1 rpc endpoint %d is healthy again", i)
+	} else {
+		logx.Errorf("l1 rpc endpoint %d is unhealthy, err: %v", i, err)
+	}
+}
+
+func (c *Client) setActive(i int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.active != i {
+		logx.Infof("fail over from l1 rpc endpoint %d to %d", c.active, i)
+		c.active = i
+	}
+}
+
+// call calls fn on the endpoints until one of them responds.
+func (c *Client) call(fn func(cli *_rpc.ProviderClient) error) error {
+	var err error
+	for _, i := range c.candidates() {
+		err = fn(c.endpoints[i].cli)
+		if err != nil && isEndpointError(err) {
+			c.markHealthy(i, false, err)
+			continue
+		}
+		c.markHealthy(i, true, nil)
+		c.setActive(i)
+		return err
+	}
+	return err
+}
+
+// callQuorum calls fn on the endpoints until n of them succeed.
+func (c *Client) callQuorum(n int, fn func(cli *_rpc.ProviderClient) error) error {
+	var (
+		succeeded int
+		err       error
+	)
+	for _, i := range c.candidates() {
+		callErr := fn(c.endpoints[i].cli)
+		if callErr != nil {
+			err = callErr
+			if isEndpointError(callErr) {
+				c.markHealthy(i, false, callErr)
+			}
+			continue
+		}
+		c.markHealthy(i, true, nil)
+		succeeded++
+		if succeeded == n {
+			return nil
+		}
+	}
+	return fmt.Errorf("no quorum of l1 rpc endpoints, %d of %d succeed, err: %v", succeeded, n, err)
+}
+
+// CheckHealth gets the heads of all the endpoints, the ones which fail or fall behind are unhealthy.
+// The active endpoint is switched to the healthy one with the highest head if it's unhealthy.
+func (c *Client) CheckHealth() {
+	heads := make([]uint64, len(c.endpoints))
+	errs := make([]error, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range c.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+			defer cancel()
+			heads[i], errs[i] = e.cli.BlockNumber(ctx)
+		}(i, e)
+	}
+	wg.Wait()
+
+	best := -1
+	for i := range c.endpoints {
+		if errs[i] == nil && (best == -1 || heads[i] > heads[best]) {
+			best = i
+		}
+	}
+	if best == -1 {
+		logx.Errorf("all the l1 rpc endpoints are unhealthy, err: %v", errs[0])
+	}
+	for i := range c.endpoints {
+		if errs[i] == nil && heads[i]+maxHeadLag < heads[best] {
+			errs[i] = fmt.Errorf("head %d falls behind %d", heads[i], heads[best])
+		}
+		c.markHealthy(i, errs[i] == nil, errs[i])
+	}
+
+	c.mu.RLock()
+	active := c.active
+	c.mu.RUnlock()
+	if !c.isHealthy(active) && best != -1 {
+		c.setActive(best)
+	}
+}
+
+// GetHeight returns the head height, it's the lower one of two endpoints with quorum reads.
+func (c *Client) GetHeight() (height uint64, err error) {
+	if !c.quorum {
+		err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+			height, err = cli.GetHeight()
+			return err
+		})
+		return height, err
+	}
+	var heights []uint64
+	err = c.callQuorum(2, func(cli *_rpc.ProviderClient) error {
+		h, err := cli.GetHeight()
+		if err == nil {
+			heights = append(heights, h)
+		}
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if heights[1] < heights[0] {
+		return heights[1], nil
+	}
+	return heights[0], nil
+}
+
+// FilterLogs returns the logs, they have to be the same from two endpoints with quorum reads.
+func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	if !c.quorum {
+		err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+			logs, err = cli.FilterLogs(ctx, query)
+			return err
+		})
+		return logs, err
+	}
+	var results [][]types.Log
+	err = c.callQuorum(2, func(cli *_rpc.ProviderClient) error {
+		l, err := cli.FilterLogs(ctx, query)
+		if err == nil {
+			results = append(results, l)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !sameLogs(results[0], results[1]) {
+		return nil, fmt.Errorf("l1 rpc endpoints disagree on the logs from %v to %v", query.FromBlock, query.ToBlock)
+	}
+	return results[0], nil
+}
+
+// SubscribeFilterLogs subscribes to the logs on one endpoint, the subscription doesn't fail over.
+func (c *Client) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (sub ethereum.Subscription, err error) {
+	err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+		sub, err = cli.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return sub, err
+}
+
+// CallContract calls the contract, the result has to be the same from two endpoints with quorum reads.
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return c.readBytes(func(cli *_rpc.ProviderClient) ([]byte, error) {
+		return cli.CallContract(ctx, msg, blockNumber)
+	}, fmt.Sprintf("the call of %v at %v", msg.To, blockNumber))
+}
+
+// CodeAt returns the contract code, it has to be the same from two endpoints with quorum reads.
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.readBytes(func(cli *_rpc.ProviderClient) ([]byte, error) {
+		return cli.CodeAt(ctx, account, blockNumber)
+	}, fmt.Sprintf("the code of %s at %v", account.Hex(), blockNumber))
+}
+
+func (c *Client) readBytes(read func(cli *_rpc.ProviderClient) ([]byte, error), what string) (result []byte, err error) {
+	if !c.quorum {
+		err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+			result, err = read(cli)
+			return err
+		})
+		return result, err
+	}
+	var results [][]byte
+	err = c.callQuorum(2, func(cli *_rpc.ProviderClient) error {
+		r, err := read(cli)
+		if err == nil {
+			results = append(results, r)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(results[0], results[1]) {
+		return nil, fmt.Errorf("l1 rpc endpoints disagree on %s", what)
+	}
+	return results[0], nil
+}
+
+func sameLogs(a, b []types.Log) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].BlockHash != b[i].BlockHash || a[i].TxHash != b[i].TxHash || a[i].Index != b[i].Index {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Client) GetBlockHeaderByNumber(height *big.Int) (header *types.Header, err error) {
+	err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+		header, err = cli.GetBlockHeaderByNumber(height)
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) GetLatestBlockHeader() (header *types.Header, err error) {
+	return c.GetBlockHeaderByNumber(nil)
+}
+
+func (c *Client) ChainID(ctx context.Context) (chainId *big.Int, err error) {
+	err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+		chainId, err = cli.ChainID(ctx)
+		return err
+	})
+	return chainId, err
+}
+
+func (c *Client) GetTransactionReceipt(txHash string) (receipt *types.Receipt, err error) {
+	err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+		receipt, err = cli.GetTransactionReceipt(txHash)
+		return err
+	})
+	return receipt, err
+}
+
+func (c *Client) GetTransactionByHash(txHash string) (tx *types.Transaction, isPending bool, err error) {
+	err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+		tx, isPending, err = cli.GetTransactionByHash(txHash)
+		return err
+	})
+	return tx, isPending, err
+}
+
+func (c *Client) GetPendingNonce(address string) (nonce uint64, err error) {
+	err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+		nonce, err = cli.GetPendingNonce(address)
+		return err
+	})
+	return nonce, err
+}
+
+func (c *Client) SuggestGasPrice(ctx context.Context) (gasPrice *big.Int, err error) {
+	err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+		gasPrice, err = cli.SuggestGasPrice(ctx)
+		return err
+	})
+	return gasPrice, err
+}
+
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (gas uint64, err error) {
+	err = c.call(func(cli *_rpc.ProviderClient) (err error) {
+		gas, err = cli.EstimateGas(ctx, msg)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction sends the tx to all the healthy endpoints, so that it is not lost if the active one
+// goes down. It succeeds if any endpoint accepts the tx.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	var (
+		sent bool
+		err  error
+	)
+	for _, i := range c.candidates() {
+		// the unhealthy endpoints are tried only until the tx is accepted
+		if sent && !c.isHealthy(i) {
+			continue
+		}
+		sendErr := c.endpoints[i].cli.SendTransaction(ctx, tx)
+		if sendErr == nil {
+			sent = true
+			continue
+		}
+		if isEndpointError(sendErr) {
+			c.markHealthy(i, false, sendErr)
+		}
+		if err == nil {
+			err = sendErr
+		}
+	}
+	if sent {
+		return nil
+	}
+	return err
+}
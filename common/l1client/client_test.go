@@ -0,0 +1,163 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package l1client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeEndpoint serves eth_blockNumber, eth_getLogs and eth_call, or fails with 500 if it is down.
+type fakeEndpoint struct {
+	head       uint64
+	txHash     int
+	callResult int
+	down       bool
+	calls      int
+}
+
+func (e *fakeEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.calls++
+	if e.down {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	//nolint:errcheck
+	json.NewDecoder(r.Body).Decode(&req)
+	var result string
+	switch req.Method {
+	case "eth_blockNumber":
+		result = fmt.Sprintf(`"0x%x"`, e.head)
+	case "eth_getLogs":
+		result = fmt.Sprintf(`[{"address":"0x0000000000000000000000000000000000000001","topics":[],"data":"0x",`+
+			`"blockNumber":"0x1","transactionHash":"0x%064x","transactionIndex":"0x0",`+
+			`"blockHash":"0x0000000000000000000000000000000000000000000000000000000000000001",`+
+			`"logIndex":"0x0","removed":false}]`, e.txHash)
+	case "eth_call":
+		result = fmt.Sprintf(`"0x%064x"`, e.callResult)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	//nolint:errcheck
+	fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, result)
+}
+
+func newTestClient(t *testing.T, quorum bool, endpoints ...*fakeEndpoint) *Client {
+	var urls []string
+	for _, e := range endpoints {
+		server := httptest.NewServer(e)
+		t.Cleanup(server.Close)
+		urls = append(urls, server.URL)
+	}
+	c, err := NewClient(urls, quorum)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestParseEndpoints(t *testing.T) {
+	assert.Equal(t, []string{"http://a", "http://b"}, ParseEndpoints(" http://a, ,http://b "))
+	assert.Empty(t, ParseEndpoints(""))
+}
+
+func TestFailover(t *testing.T) {
+	first := &fakeEndpoint{head: 100, down: true}
+	second := &fakeEndpoint{head: 101}
+	c := newTestClient(t, false, first, second)
+
+	height, err := c.GetHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(101), height)
+	assert.Equal(t, 1, c.active)
+	assert.False(t, c.isHealthy(0))
+
+	// The active endpoint is kept after the failed one recovers.
+	first.down = false
+	_, err = c.GetHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, first.calls)
+
+	// The health check marks the recovered endpoint healthy, and the lagging one unhealthy.
+	first.head = 200
+	c.CheckHealth()
+	assert.True(t, c.isHealthy(0))
+	assert.False(t, c.isHealthy(1))
+	assert.Equal(t, 0, c.active)
+
+	// All endpoints are down.
+	first.down, second.down = true, true
+	_, err = c.GetHeight()
+	assert.Error(t, err)
+}
+
+func TestQuorumReads(t *testing.T) {
+	_, err := NewClient([]string{"http://localhost:8545"}, true)
+	assert.Error(t, err)
+
+	first := &fakeEndpoint{head: 100, txHash: 1}
+	second := &fakeEndpoint{head: 98, txHash: 1}
+	c := newTestClient(t, true, first, second)
+
+	height, err := c.GetHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(98), height)
+	logs, err := c.FilterLogs(context.Background(), ethereum.FilterQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+
+	second.txHash = 2
+	_, err = c.FilterLogs(context.Background(), ethereum.FilterQuery{})
+	assert.Error(t, err)
+
+	// No quorum with one endpoint down.
+	second.down = true
+	_, err = c.GetHeight()
+	assert.Error(t, err)
+}
+
+func TestCallContract(t *testing.T) {
+	to := common.HexToAddress("0x01")
+	msg := ethereum.CallMsg{To: &to}
+
+	first := &fakeEndpoint{callResult: 1, down: true}
+	second := &fakeEndpoint{callResult: 1}
+	c := newTestClient(t, false, first, second)
+	result, err := c.CallContract(context.Background(), msg, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, common.LeftPadBytes([]byte{1}, 32), result)
+	assert.Equal(t, 1, c.active)
+
+	// The results of the endpoints have to agree with quorum reads.
+	first.down = false
+	c = newTestClient(t, true, first, second)
+	_, err = c.CallContract(context.Background(), msg, nil)
+	assert.NoError(t, err)
+	second.callResult = 2
+	_, err = c.CallContract(context.Background(), msg, nil)
+	assert.Error(t, err)
+}
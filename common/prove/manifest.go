@@ -0,0 +1,365 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prove
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	cryptoBlock "github.com/bnb-chain/zkbnb-crypto/legend/circuit/bn254/block"
+)
+
+// VerifierParamsCount is the number of the verifying key values hardcoded in the on-chain
+// verifier for one block size, 14 values of vk and 8 values of gammaABC.
+const VerifierParamsCount = 22
+
+// KeyManifest records the circuits and keys used for each block size, the keys are matched by
+// block size instead of by the order in the config.
+type KeyManifest struct {
+	Keys []*KeyManifestEntry
+}
+
+type KeyManifestEntry struct {
+	BlockSize int
+	// Sha256 of the compiled circuit.
+	CircuitHash string
+	// Sha256 of the proving key and verifying key files.
+	ProvingKeyHash   string
+	VerifyingKeyHash string
+	// Verifying key values of the on-chain verifier, in the same order as the output of verifier_parse.py.
+	VerifierParams []string
+}
+
+// BlockKeys is the circuit and key files used to prove the blocks of one block size.
+type BlockKeys struct {
+	BlockSize        int
+	R1cs             frontend.CompiledConstraintSystem
+	ProvingKeyPath   string
+	VerifyingKeyPath string
+	VerifyingKey     groth16.VerifyingKey
+}
+
+// CompileBlockCircuit compiles the block circuit of the block size.
+func CompileBlockCircuit(blockSize int) (frontend.CompiledConstraintSystem, error) {
+	var circuit cryptoBlock.BlockConstraints
+	circuit.TxsCount = blockSize
+	circuit.Txs = make([]cryptoBlock.TxConstraints, blockSize)
+	for i := 0; i < blockSize; i++ {
+		circuit.Txs[i] = cryptoBlock.GetZeroTxConstraint()
+	}
+	return frontend.Compile(ecc.BN254, r1cs.NewBuilder, &circuit, frontend.IgnoreUnconstrainedInputs())
+}
+
+func LoadKeyManifest(path string) (*KeyManifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &KeyManifest{}
+	err = json.Unmarshal(content, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key manifest, err: %v", err)
+	}
+	return manifest, nil
+}
+
+func (m *KeyManifest) Save(path string) error {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+func (m *KeyManifest) Entry(blockSize int) *KeyManifestEntry {
+	for _, entry := range m.Keys {
+		if entry.BlockSize == blockSize {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Verify checks that the circuit and key files of the block size are the ones in the manifest.
+func (m *KeyManifest) Verify(keys *BlockKeys) error {
+	expected := m.Entry(keys.BlockSize)
+	if expected == nil {
+		return fmt.Errorf("block size %d is not in the key manifest", keys.BlockSize)
+	}
+	actual, err := NewKeyManifestEntry(keys)
+	if err != nil {
+		return err
+	}
+	if actual.CircuitHash != expected.CircuitHash {
+		return fmt.Errorf("circuit of block size %d does not match the manifest", keys.BlockSize)
+	}
+	if actual.ProvingKeyHash != expected.ProvingKeyHash {
+		return fmt.Errorf("proving key %s does not match the manifest of block size %d", keys.ProvingKeyPath, keys.BlockSize)
+	}
+	if actual.VerifyingKeyHash != expected.VerifyingKeyHash {
+		return fmt.Errorf("verifying key %s does not match the manifest of block size %d", keys.VerifyingKeyPath, keys.BlockSize)
+	}
+	return CheckVerifierParams(keys.BlockSize, expected.VerifierParams, actual.VerifierParams)
+}
+
+// VerifyVerifyingKey checks the verifying key of the block size, it's used by the services which
+// only hold the verifying keys.
+func (m *KeyManifest) VerifyVerifyingKey(blockSize int, path string, verifyingKey groth16.VerifyingKey) error {
+	expected := m.Entry(blockSize)
+	if expected == nil {
+		return fmt.Errorf("block size %d is not in the key manifest", blockSize)
+	}
+	hash, err := FileHash(path)
+	if err != nil {
+		return err
+	}
+	if hash != expected.VerifyingKeyHash {
+		return fmt.Errorf("verifying key %s does not match the manifest of block size %d", path, blockSize)
+	}
+	params, err := VerifierParams(verifyingKey)
+	if err != nil {
+		return err
+	}
+	return CheckVerifierParams(blockSize, expected.VerifierParams, params)
+}
+
+func NewKeyManifestEntry(keys *BlockKeys) (*KeyManifestEntry, error) {
+	circuitHash := sha256.New()
+	_, err := keys.R1cs.WriteTo(circuitHash)
+	if err != nil {
+		return nil, err
+	}
+	provingKeyHash, err := FileHash(keys.ProvingKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	verifyingKeyHash, err := FileHash(keys.VerifyingKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	verifierParams, err := VerifierParams(keys.VerifyingKey)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyManifestEntry{
+		BlockSize:        keys.BlockSize,
+		CircuitHash:      hex.EncodeToString(circuitHash.Sum(nil)),
+		ProvingKeyHash:   provingKeyHash,
+		VerifyingKeyHash: verifyingKeyHash,
+		VerifierParams:   verifierParams,
+	}, nil
+}
+
+func FileHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// VerifierParams returns the values of the verifying key as they are hardcoded in the on-chain
+// verifier, alpha1, beta2, gamma2, delta2 and then the points of IC.
+func VerifierParams(verifyingKey groth16.VerifyingKey) ([]string, error) {
+	vk, err := parseVerifyingKeyPoints(verifyingKey)
+	if err != nil {
+		return nil, err
+	}
+	params := []string{vk.alpha.X.String(), vk.alpha.Y.String()}
+	for _, p := range []interface{ String() string }{
+		&vk.beta.X.A1, &vk.beta.X.A0, &vk.beta.Y.A1, &vk.beta.Y.A0,
+		&vk.gamma.X.A1, &vk.gamma.X.A0, &vk.gamma.Y.A1, &vk.gamma.Y.A0,
+		&vk.delta.X.A1, &vk.delta.X.A0, &vk.delta.Y.A1, &vk.delta.Y.A0,
+	} {
+		params = append(params, p.String())
+	}
+	for i := range vk.k {
+		params = append(params, vk.k[i].X.String(), vk.k[i].Y.String())
+	}
+	if len(params) != VerifierParamsCount {
+		return nil, fmt.Errorf("invalid verifying key, %d public inputs", len(vk.k)-1)
+	}
+	return params, nil
+}
+
+// verifyingKeyPoints holds the points of a groth16 verifying key which are hardcoded in the verifier.
+type verifyingKeyPoints struct {
+	alpha bn254.G1Affine
+	beta  bn254.G2Affine
+	gamma bn254.G2Affine
+	delta bn254.G2Affine
+	k     []bn254.G1Affine
+}
+
+// parseVerifyingKeyPoints reads the points from the raw encoding of the verifying key, which is
+// [α]1,[β]1,[β]2,[γ]2,[δ]1,[δ]2,uint32(len(Kvk)),[Kvk]1.
+func parseVerifyingKeyPoints(verifyingKey groth16.VerifyingKey) (*verifyingKeyPoints, error) {
+	var raw bytes.Buffer
+	_, err := verifyingKey.WriteRawTo(&raw)
+	if err != nil {
+		return nil, err
+	}
+	vk := &verifyingKeyPoints{}
+	var beta1, delta1 bn254.G1Affine
+	dec := bn254.NewDecoder(&raw)
+	for _, v := range []interface{}{&vk.alpha, &beta1, &vk.beta, &vk.gamma, &delta1, &vk.delta, &vk.k} {
+		if err := dec.Decode(v); err != nil {
+			return nil, fmt.Errorf("invalid verifying key, err: %v", err)
+		}
+	}
+	if len(vk.k) == 0 {
+		return nil, errors.New("invalid verifying key")
+	}
+	return vk, nil
+}
+
+func CheckVerifierParams(blockSize int, expected, actual []string) error {
+	if len(expected) != len(actual) {
+		return fmt.Errorf("verifier of block size %d does not match the verifying key", blockSize)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			return fmt.Errorf("verifier of block size %d does not match the verifying key", blockSize)
+		}
+	}
+	return nil
+}
+
+var (
+	verifierBlockSizeRegexp = regexp.MustCompile(`block_size == (\d+)`)
+	verifierParamRegexp     = regexp.MustCompile(`(vk|gammaABC)\[(\d+)\] = (\d+);`)
+)
+
+// LoadVerifierParams reads the verifying keys of each block size from the verifier contract
+// updated by verifier_parse.py.
+func LoadVerifierParams(contractPath string) (map[int][]string, error) {
+	f, err := os.Open(contractPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	params := make(map[int][]string)
+	blockSize := -1
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := verifierBlockSizeRegexp.FindStringSubmatch(line); match != nil {
+			blockSize, _ = strconv.Atoi(match[1])
+			if params[blockSize] == nil {
+				params[blockSize] = make([]string, VerifierParamsCount)
+			}
+			continue
+		}
+		match := verifierParamRegexp.FindStringSubmatch(line)
+		if match == nil || blockSize < 0 {
+			continue
+		}
+		index, _ := strconv.Atoi(match[2])
+		if match[1] == "gammaABC" {
+			index += 14
+		}
+		if index >= VerifierParamsCount {
+			return nil, fmt.Errorf("invalid verifier param %s of block size %d", match[0], blockSize)
+		}
+		params[blockSize][index] = match[3]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// VerifyBlockKeys checks the keys against the manifest and against the verifier contract, the
+// checks are skipped if the paths are empty.
+func VerifyBlockKeys(manifestPath, verifierPath string, keys []*BlockKeys) error {
+	if manifestPath != "" {
+		manifest, err := LoadKeyManifest(manifestPath)
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := manifest.Verify(k); err != nil {
+				return err
+			}
+		}
+	}
+	if verifierPath != "" {
+		verifierParams, err := LoadVerifierParams(verifierPath)
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			params, err := VerifierParams(k.VerifyingKey)
+			if err != nil {
+				return err
+			}
+			if err := CheckVerifierParams(k.BlockSize, verifierParams[k.BlockSize], params); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LoadVerifyingKeys loads the verifying keys indexed by block size, every key is checked against
+// the manifest so that misordered keys are rejected.
+func LoadVerifyingKeys(manifestPath string, blockSizes []int, verifyingKeyPaths []string) (map[int]groth16.VerifyingKey, error) {
+	if manifestPath == "" {
+		return nil, errors.New("key manifest is not configured")
+	}
+	if len(verifyingKeyPaths) != len(blockSizes) {
+		return nil, errors.New("verifying keys do not match block sizes")
+	}
+	manifest, err := LoadKeyManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	verifyingKeys := make(map[int]groth16.VerifyingKey, len(blockSizes))
+	for i, blockSize := range blockSizes {
+		verifyingKey, err := LoadVerifyingKey(verifyingKeyPaths[i])
+		if err != nil {
+			return nil, fmt.Errorf("load verifying key %s error, err: %v", verifyingKeyPaths[i], err)
+		}
+		if err := manifest.VerifyVerifyingKey(blockSize, verifyingKeyPaths[i], verifyingKey); err != nil {
+			return nil, err
+		}
+		verifyingKeys[blockSize] = verifyingKey
+	}
+	return verifyingKeys, nil
+}
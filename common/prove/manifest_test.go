@@ -0,0 +1,155 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prove
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/assert"
+)
+
+// rootsCircuit has the same number of public inputs as the block circuit.
+type rootsCircuit struct {
+	X          frontend.Variable
+	OldRoot    frontend.Variable `gnark:",public"`
+	NewRoot    frontend.Variable `gnark:",public"`
+	Commitment frontend.Variable `gnark:",public"`
+}
+
+func (c *rootsCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), api.Add(c.OldRoot, c.NewRoot, c.Commitment))
+	return nil
+}
+
+func writeKey(t *testing.T, path string, key interface {
+	WriteTo(w io.Writer) (int64, error)
+}) {
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	_, err = key.WriteTo(f)
+	assert.NoError(t, err)
+}
+
+func writeVerifier(t *testing.T, path string, blockSize int, params []string) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("        if (block_size == %d) {\n", blockSize))
+	for i := 0; i < 14; i++ {
+		sb.WriteString(fmt.Sprintf("            vk[%d] = %s;\n", i, params[i]))
+	}
+	sb.WriteString("        } else {\n")
+	sb.WriteString(fmt.Sprintf("        if (block_size == %d) {\n", blockSize))
+	for i := 0; i < 8; i++ {
+		sb.WriteString(fmt.Sprintf("            gammaABC[%d] = %s;\n", i, params[14+i]))
+	}
+	sb.WriteString("        } else {\n")
+	assert.NoError(t, os.WriteFile(path, []byte(sb.String()), 0644))
+}
+
+func TestKeyManifest(t *testing.T) {
+	dir := t.TempDir()
+	ccs, err := frontend.Compile(ecc.BN254, r1cs.NewBuilder, &rootsCircuit{})
+	assert.NoError(t, err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(t, err)
+	_, otherVk, err := groth16.Setup(ccs)
+	assert.NoError(t, err)
+	writeKey(t, filepath.Join(dir, "zkbnb1.pk"), pk)
+	writeKey(t, filepath.Join(dir, "zkbnb1.vk"), vk)
+	writeKey(t, filepath.Join(dir, "zkbnb10.vk"), otherVk)
+
+	keys := &BlockKeys{
+		BlockSize:        1,
+		R1cs:             ccs,
+		ProvingKeyPath:   filepath.Join(dir, "zkbnb1.pk"),
+		VerifyingKeyPath: filepath.Join(dir, "zkbnb1.vk"),
+		VerifyingKey:     vk,
+	}
+	entry, err := NewKeyManifestEntry(keys)
+	assert.NoError(t, err)
+	assert.Len(t, entry.VerifierParams, VerifierParamsCount)
+	manifestPath := filepath.Join(dir, "manifest.json")
+	assert.NoError(t, (&KeyManifest{Keys: []*KeyManifestEntry{entry}}).Save(manifestPath))
+	verifierPath := filepath.Join(dir, "ZkBNBVerifier.sol")
+	writeVerifier(t, verifierPath, 1, entry.VerifierParams)
+
+	verifierParams, err := LoadVerifierParams(verifierPath)
+	assert.NoError(t, err)
+	assert.Equal(t, entry.VerifierParams, verifierParams[1])
+	assert.NoError(t, VerifyBlockKeys(manifestPath, verifierPath, []*BlockKeys{keys}))
+
+	// Misordered keys are rejected.
+	misordered := *keys
+	misordered.VerifyingKeyPath = filepath.Join(dir, "zkbnb10.vk")
+	misordered.VerifyingKey = otherVk
+	assert.Error(t, VerifyBlockKeys(manifestPath, "", []*BlockKeys{&misordered}))
+	assert.Error(t, VerifyBlockKeys("", verifierPath, []*BlockKeys{&misordered}))
+
+	// Unknown block sizes are rejected.
+	unknown := *keys
+	unknown.BlockSize = 10
+	assert.Error(t, VerifyBlockKeys(manifestPath, "", []*BlockKeys{&unknown}))
+	assert.Error(t, VerifyBlockKeys("", verifierPath, []*BlockKeys{&unknown}))
+}
+
+func TestLoadVerifyingKeys(t *testing.T) {
+	dir := t.TempDir()
+	ccs, err := frontend.Compile(ecc.BN254, r1cs.NewBuilder, &rootsCircuit{})
+	assert.NoError(t, err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(t, err)
+	_, otherVk, err := groth16.Setup(ccs)
+	assert.NoError(t, err)
+	writeKey(t, filepath.Join(dir, "zkbnb1.pk"), pk)
+	writeKey(t, filepath.Join(dir, "zkbnb1.vk"), vk)
+	writeKey(t, filepath.Join(dir, "zkbnb10.vk"), otherVk)
+
+	entry, err := NewKeyManifestEntry(&BlockKeys{
+		BlockSize:        1,
+		R1cs:             ccs,
+		ProvingKeyPath:   filepath.Join(dir, "zkbnb1.pk"),
+		VerifyingKeyPath: filepath.Join(dir, "zkbnb1.vk"),
+		VerifyingKey:     vk,
+	})
+	assert.NoError(t, err)
+	manifestPath := filepath.Join(dir, "manifest.json")
+	assert.NoError(t, (&KeyManifest{Keys: []*KeyManifestEntry{entry}}).Save(manifestPath))
+
+	verifyingKeys, err := LoadVerifyingKeys(manifestPath, []int{1}, []string{filepath.Join(dir, "zkbnb1.vk")})
+	assert.NoError(t, err)
+	assert.Len(t, verifyingKeys, 1)
+
+	// The manifest is mandatory.
+	_, err = LoadVerifyingKeys("", []int{1}, []string{filepath.Join(dir, "zkbnb1.vk")})
+	assert.Error(t, err)
+	// Misordered keys are rejected.
+	_, err = LoadVerifyingKeys(manifestPath, []int{1}, []string{filepath.Join(dir, "zkbnb10.vk")})
+	assert.Error(t, err)
+	// Unknown block sizes are rejected.
+	_, err = LoadVerifyingKeys(manifestPath, []int{10}, []string{filepath.Join(dir, "zkbnb1.vk")})
+	assert.Error(t, err)
+}
@@ -107,3 +107,52 @@ func FormatProof(oProof groth16.Proof, oldRoot, newRoot, commitment []byte) (pro
 	proof.Inputs[2] = new(big.Int).SetBytes(commitment)
 	return proof, nil
 }
+
+// ParseProof converts the formatted proof back to the groth16 proof, it is the reverse of FormatProof.
+func ParseProof(proof *FormattedProof) (groth16.Proof, error) {
+	const fpSize = 4 * 8
+	elements := []*big.Int{
+		proof.A[0], proof.A[1],
+		proof.B[0][0], proof.B[0][1], proof.B[1][0], proof.B[1][1],
+		proof.C[0], proof.C[1],
+	}
+	proofBytes := make([]byte, fpSize*len(elements))
+	for i, element := range elements {
+		if element == nil || element.Sign() < 0 || element.BitLen() > fpSize*8 {
+			return nil, fmt.Errorf("invalid proof element %d", i)
+		}
+		element.FillBytes(proofBytes[fpSize*i : fpSize*(i+1)])
+	}
+
+	oProof := groth16.NewProof(ecc.BN254)
+	_, err := oProof.ReadFrom(bytes.NewReader(proofBytes))
+	if err != nil {
+		return nil, err
+	}
+	return oProof, nil
+}
+
+// VerifyProof verifies the formatted proof with the verifying key, the public inputs of the proof
+// must be the state roots and the commitment of the block.
+func VerifyProof(proof *FormattedProof, verifyingKey groth16.VerifyingKey, oldRoot, newRoot, commitment []byte) error {
+	inputs := [][]byte{oldRoot, newRoot, commitment}
+	for i, input := range inputs {
+		if proof.Inputs[i] == nil || proof.Inputs[i].Cmp(new(big.Int).SetBytes(input)) != 0 {
+			return fmt.Errorf("public input %d mismatch", i)
+		}
+	}
+
+	oProof, err := ParseProof(proof)
+	if err != nil {
+		return err
+	}
+	var verifyWitness cryptoBlock.BlockConstraints
+	verifyWitness.OldStateRoot = oldRoot
+	verifyWitness.NewStateRoot = newRoot
+	verifyWitness.BlockCommitment = commitment
+	vWitness, err := frontend.NewWitness(&verifyWitness, ecc.BN254, frontend.PublicOnly())
+	if err != nil {
+		return err
+	}
+	return groth16.Verify(oProof, verifyingKey, vWitness)
+}
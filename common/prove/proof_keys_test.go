@@ -0,0 +1,88 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prove
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/assert"
+)
+
+type squareCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func TestParseProof(t *testing.T) {
+	ccs, err := frontend.Compile(ecc.BN254, r1cs.NewBuilder, &squareCircuit{})
+	assert.NoError(t, err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(t, err)
+	witness, err := frontend.NewWitness(&squareCircuit{X: 3, Y: 9}, ecc.BN254)
+	assert.NoError(t, err)
+	publicWitness, err := frontend.NewWitness(&squareCircuit{Y: 9}, ecc.BN254, frontend.PublicOnly())
+	assert.NoError(t, err)
+	oProof, err := groth16.Prove(ccs, pk, witness)
+	assert.NoError(t, err)
+
+	formattedProof, err := FormatProof(oProof, []byte{1}, []byte{2}, []byte{3})
+	assert.NoError(t, err)
+	parsedProof, err := ParseProof(formattedProof)
+	assert.NoError(t, err)
+	assert.NoError(t, groth16.Verify(parsedProof, vk, publicWitness))
+
+	var expected, actual bytes.Buffer
+	_, err = oProof.WriteRawTo(&expected)
+	assert.NoError(t, err)
+	_, err = parsedProof.WriteRawTo(&actual)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Bytes(), actual.Bytes())
+
+	// Tampered proofs are rejected either by parsing or by verification.
+	formattedProof.A[0] = new(big.Int).Add(formattedProof.A[0], big.NewInt(1))
+	parsedProof, err = ParseProof(formattedProof)
+	if err == nil {
+		assert.Error(t, groth16.Verify(parsedProof, vk, publicWitness))
+	}
+
+	formattedProof.C[1] = nil
+	_, err = ParseProof(formattedProof)
+	assert.Error(t, err)
+}
+
+func TestVerifyProofInputs(t *testing.T) {
+	formattedProof := &FormattedProof{
+		Inputs: [3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)},
+	}
+	verifyingKey := groth16.NewVerifyingKey(ecc.BN254)
+	assert.EqualError(t, VerifyProof(formattedProof, verifyingKey, []byte{1}, []byte{2}, []byte{4}), "public input 2 mismatch")
+	assert.EqualError(t, VerifyProof(formattedProof, verifyingKey, []byte{2}, []byte{2}, []byte{3}), "public input 0 mismatch")
+	// Inputs are matched, but the proof itself is missing.
+	assert.Error(t, VerifyProof(formattedProof, verifyingKey, []byte{1}, []byte{2}, []byte{3}))
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prove
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+
+	cryptoBlock "github.com/bnb-chain/zkbnb-crypto/legend/circuit/bn254/block"
+	"github.com/bnb-chain/zkbnb-crypto/legend/circuit/bn254/std"
+)
+
+// BlockConstraintsIndex is the tx index of the constraints which are on the whole block, such as the
+// state roots and the block commitment.
+const BlockConstraintsIndex = -1
+
+// ConstraintError is the first constraint of the block circuit which the witness doesn't satisfy.
+type ConstraintError struct {
+	BlockHeight int64
+	// Index of the failing tx in the block, or BlockConstraintsIndex.
+	TxIndex int
+	TxType  int64
+	// The failing assertion and where it is in the circuit.
+	Constraint string
+	Location   string
+}
+
+func (e *ConstraintError) Error() string {
+	if e.TxIndex == BlockConstraintsIndex {
+		return fmt.Sprintf("block %d doesn't satisfy the block constraints: %s at %s",
+			e.BlockHeight, e.Constraint, e.Location)
+	}
+	return fmt.Sprintf("tx %d (type %d) of block %d doesn't satisfy the constraints: %s at %s",
+		e.TxIndex, e.TxType, e.BlockHeight, e.Constraint, e.Location)
+}
+
+// txCircuit verifies a single tx at the creation time of its block.
+type txCircuit struct {
+	Tx        cryptoBlock.TxConstraints
+	CreatedAt cryptoBlock.Variable
+}
+
+func (circuit txCircuit) Define(api cryptoBlock.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	_, _, err = cryptoBlock.VerifyTransaction(api, circuit.Tx, hFunc, circuit.CreatedAt)
+	return err
+}
+
+// CheckBlockWitness evaluates the block circuit on the witness without generating a proof. If the
+// witness doesn't satisfy it, the txs are checked one by one to report the first failing tx.
+func CheckBlockWitness(b *cryptoBlock.Block) (err error) {
+	// Malformed witnesses may panic when they are assigned to the circuit.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid witness of block %d: %v", b.BlockNumber, r)
+		}
+	}()
+	blockWitness, err := cryptoBlock.SetBlockWitness(b)
+	if err != nil {
+		return err
+	}
+	var circuit cryptoBlock.BlockConstraints
+	circuit.TxsCount = len(b.Txs)
+	circuit.Txs = make([]cryptoBlock.TxConstraints, len(b.Txs))
+	for i := range circuit.Txs {
+		circuit.Txs[i] = cryptoBlock.GetZeroTxConstraint()
+	}
+	blockWitness.TxsCount = len(b.Txs)
+	blockErr := test.IsSolved(&circuit, &blockWitness, ecc.BN254, backend.GROTH16,
+		backend.WithHints(std.Keccak256, std.ComputeSLp))
+	if blockErr == nil {
+		return nil
+	}
+
+	for i, tx := range blockWitness.Txs {
+		emptyTx := txCircuit{Tx: cryptoBlock.GetZeroTxConstraint()}
+		assignment := txCircuit{Tx: tx, CreatedAt: b.CreatedAt}
+		err = test.IsSolved(&emptyTx, &assignment, ecc.BN254, backend.GROTH16)
+		if err != nil {
+			constraintErr := newConstraintError(b.BlockNumber, i, err)
+			constraintErr.TxType = int64(b.Txs[i].TxType)
+			return constraintErr
+		}
+	}
+	return newConstraintError(b.BlockNumber, BlockConstraintsIndex, blockErr)
+}
+
+// newConstraintError parses the error of the test engine, which is the failed assertion followed by
+// the circuit stack with the function and file of each frame. The location is the frames from the
+// failed assertion up to the first frame in the block circuit, the std gadgets are shared by the txs.
+func newConstraintError(height int64, txIndex int, err error) *ConstraintError {
+	lines := strings.Split(strings.TrimSpace(err.Error()), "\n")
+	constraintErr := &ConstraintError{
+		BlockHeight: height,
+		TxIndex:     txIndex,
+		Constraint:  strings.TrimSpace(lines[0]),
+		Location:    "unknown",
+	}
+	var frames []string
+	for i := 1; i+1 < len(lines); i += 2 {
+		function := strings.TrimSpace(lines[i])
+		frames = append(frames, function+" "+strings.TrimSpace(lines[i+1]))
+		if strings.HasPrefix(function, "block.") {
+			break
+		}
+	}
+	if len(frames) > 0 {
+		constraintErr.Location = strings.Join(frames, " < ")
+	}
+	return constraintErr
+}
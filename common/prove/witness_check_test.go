@@ -0,0 +1,67 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prove
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	cryptoBlock "github.com/bnb-chain/zkbnb-crypto/legend/circuit/bn254/block"
+	"github.com/bnb-chain/zkbnb-crypto/legend/circuit/bn254/std"
+	"github.com/bnb-chain/zkbnb/common/chain"
+)
+
+// emptyBlock returns the witness of a block with only empty txs.
+func emptyBlock(height int64, size int) *cryptoBlock.Block {
+	b := &cryptoBlock.Block{
+		BlockNumber:  height,
+		CreatedAt:    1660000000000,
+		OldStateRoot: make([]byte, 32),
+		NewStateRoot: make([]byte, 32),
+	}
+	for i := 0; i < size; i++ {
+		b.Txs = append(b.Txs, cryptoBlock.EmptyTx())
+	}
+	pubData := make([]byte, size*std.PubDataSizePerTx*32)
+	b.BlockCommitment = common.FromHex(chain.CreateBlockCommitment(height, b.CreatedAt, b.OldStateRoot, b.NewStateRoot, pubData, 0))
+	return b
+}
+
+func TestCheckBlockWitness(t *testing.T) {
+	assert.NoError(t, CheckBlockWitness(emptyBlock(1, 2)))
+
+	b := emptyBlock(1, 2)
+	b.BlockCommitment = make([]byte, 32)
+	err := CheckBlockWitness(b)
+	constraintErr, ok := err.(*ConstraintError)
+	assert.True(t, ok)
+	assert.Equal(t, BlockConstraintsIndex, constraintErr.TxIndex)
+
+	b = emptyBlock(1, 2)
+	b.Txs[1].TxType = std.TxTypeDeposit
+	b.Txs[1].DepositTxInfo = &cryptoBlock.DepositTx{AccountNameHash: make([]byte, 32), AssetAmount: big.NewInt(0)}
+	err = CheckBlockWitness(b)
+	constraintErr, ok = err.(*ConstraintError)
+	assert.True(t, ok)
+	assert.Equal(t, 1, constraintErr.TxIndex)
+	assert.Equal(t, int64(std.TxTypeDeposit), constraintErr.TxType)
+	assert.Contains(t, constraintErr.Location, "block.VerifyTransaction")
+}
@@ -20,6 +20,7 @@ package prove
 import (
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -169,6 +170,15 @@ func (w *WitnessHelper) constructWitnessInfo(
 	return cryptoTx, nil
 }
 
+// accountAssetsWitness is the asset part of the account witness, it only depends on the asset tree of the account.
+type accountAssetsWitness struct {
+	cryptoAccount *CryptoAccount
+	// before account asset merkle proof
+	merkleProofsAccountAssetsBefore [NbAccountAssetsPerAccount][AssetMerkleLevels][]byte
+	// asset root after the asset tree is updated
+	assetRootAfter []byte
+}
+
 func (w *WitnessHelper) constructAccountWitness(
 	oTx *Tx,
 	finalityBlockNr uint64,
@@ -188,12 +198,17 @@ func (w *WitnessHelper) constructAccountWitness(
 	var (
 		accountCount = 0
 	)
+	// The asset trees of different accounts are independent, so their merkle proofs are collected
+	// concurrently, the account tree is still updated one account after another.
+	var assetsWitness []*accountAssetsWitness
+	if proverAccounts != nil {
+		assetsWitness, err = w.constructAccountAssetsWitnesses(accountKeys, proverAccounts)
+		if err != nil {
+			return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
+		}
+	}
 	for _, accountKey := range accountKeys {
-		var (
-			cryptoAccount = new(CryptoAccount)
-			// get account asset before
-			assetCount = 0
-		)
+		var assetWitness *accountAssetsWitness
 		// get account before
 		accountMerkleProofs, err := w.accountTree.GetProof(uint64(accountKey))
 		if err != nil {
@@ -210,7 +225,10 @@ func (w *WitnessHelper) constructAccountWitness(
 				return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
 			}
 			*w.assetTrees = append(*w.assetTrees, emptyAccountAssetTree)
-			cryptoAccount = std.EmptyAccount(accountKey, tree.NilAccountAssetRoot)
+			assetWitness, err = w.constructAccountAssetsWitness(accountKey, nil)
+			if err != nil {
+				return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
+			}
 			// update account info
 			accountInfo, err := w.accountModel.GetConfirmedAccountByIndex(accountKey)
 			if err != nil {
@@ -231,78 +249,10 @@ func (w *WitnessHelper) constructAccountWitness(
 				},
 			})
 		} else {
-			proverAccountInfo := proverAccounts[accountCount]
-			pk, err := common2.ParsePubKey(proverAccountInfo.AccountInfo.PublicKey)
-			if err != nil {
-				return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-			}
-			cryptoAccount = &CryptoAccount{
-				AccountIndex:    accountKey,
-				AccountNameHash: common.FromHex(proverAccountInfo.AccountInfo.AccountNameHash),
-				AccountPk:       pk,
-				Nonce:           proverAccountInfo.AccountInfo.Nonce,
-				CollectionNonce: proverAccountInfo.AccountInfo.CollectionNonce,
-				AssetRoot:       (*w.assetTrees)[accountKey].Root(),
-			}
-			for i, accountAsset := range proverAccountInfo.AccountAssets {
-				assetMerkleProof, err := (*w.assetTrees)[accountKey].GetProof(uint64(accountAsset.AssetId))
-				if err != nil {
-					return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-				}
-				// set crypto account asset
-				cryptoAccount.AssetsInfo[assetCount] = &CryptoAccountAsset{
-					AssetId:                  accountAsset.AssetId,
-					Balance:                  accountAsset.Balance,
-					LpAmount:                 accountAsset.LpAmount,
-					OfferCanceledOrFinalized: accountAsset.OfferCanceledOrFinalized,
-				}
-
-				// set merkle proof
-				merkleProofsAccountAssetsBefore[accountCount][assetCount], err = SetFixedAccountAssetArray(assetMerkleProof)
-				if err != nil {
-					return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-				}
-				// update asset merkle tree
-				nBalance, err := chain.ComputeNewBalance(
-					proverAccountInfo.AssetsRelatedTxDetails[i].AssetType,
-					proverAccountInfo.AssetsRelatedTxDetails[i].Balance,
-					proverAccountInfo.AssetsRelatedTxDetails[i].BalanceDelta,
-				)
-				if err != nil {
-					return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-				}
-				nAsset, err := types.ParseAccountAsset(nBalance)
-				if err != nil {
-					return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-				}
-				nAssetHash, err := tree.ComputeAccountAssetLeafHash(nAsset.Balance.String(), nAsset.LpAmount.String(), nAsset.OfferCanceledOrFinalized.String())
-				if err != nil {
-					return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-				}
-				err = (*w.assetTrees)[accountKey].Set(uint64(accountAsset.AssetId), nAssetHash)
-				if err != nil {
-					return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-				}
-
-				assetCount++
-			}
-			if err != nil {
-				return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-			}
-		}
-		// padding empty account asset
-		for assetCount < NbAccountAssetsPerAccount {
-			cryptoAccount.AssetsInfo[assetCount] = std.EmptyAccountAsset(LastAccountAssetId)
-			assetMerkleProof, err := (*w.assetTrees)[accountKey].GetProof(LastAccountAssetId)
-			if err != nil {
-				return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-			}
-			merkleProofsAccountAssetsBefore[accountCount][assetCount], err = SetFixedAccountAssetArray(assetMerkleProof)
-			if err != nil {
-				return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-			}
-			assetCount++
+			assetWitness = assetsWitness[accountCount]
 		}
+		cryptoAccount := assetWitness.cryptoAccount
+		merkleProofsAccountAssetsBefore[accountCount] = assetWitness.merkleProofsAccountAssetsBefore
 		// set account merkle proof
 		merkleProofsAccountBefore[accountCount], err = SetFixedAccountArray(accountMerkleProofs)
 		if err != nil {
@@ -322,7 +272,7 @@ func (w *WitnessHelper) constructAccountWitness(
 			proverAccounts[accountCount].AccountInfo.PublicKey,
 			nonce,
 			collectionNonce,
-			(*w.assetTrees)[accountKey].Root(),
+			assetWitness.assetRootAfter,
 		)
 		if err != nil {
 			return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
@@ -336,9 +286,6 @@ func (w *WitnessHelper) constructAccountWitness(
 		// add count
 		accountCount++
 	}
-	if err != nil {
-		return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, err
-	}
 	// padding empty account
 	emptyAssetTree, err := tree.NewMemAccountAssetTree()
 	if err != nil {
@@ -372,6 +319,133 @@ func (w *WitnessHelper) constructAccountWitness(
 	return accountRootBefore, accountsInfoBefore, merkleProofsAccountAssetsBefore, merkleProofsAccountBefore, nil
 }
 
+// constructAccountAssetsWitnesses constructs the asset witnesses of the accounts, it runs concurrently
+// unless an account shows up more than once, in which case the later one depends on the earlier one.
+func (w *WitnessHelper) constructAccountAssetsWitnesses(accountKeys []int64, proverAccounts []*AccountWitnessInfo) (
+	[]*accountAssetsWitness, error) {
+	assetsWitness := make([]*accountAssetsWitness, len(accountKeys))
+	errs := make([]error, len(accountKeys))
+	concurrent := len(accountKeys) > 1
+	seen := make(map[int64]bool, len(accountKeys))
+	for _, accountKey := range accountKeys {
+		if seen[accountKey] {
+			concurrent = false
+		}
+		seen[accountKey] = true
+	}
+
+	if !concurrent {
+		for i, accountKey := range accountKeys {
+			assetsWitness[i], errs[i] = w.constructAccountAssetsWitness(accountKey, proverAccounts[i])
+			if errs[i] != nil {
+				return nil, errs[i]
+			}
+		}
+		return assetsWitness, nil
+	}
+
+	var wg sync.WaitGroup
+	for i, accountKey := range accountKeys {
+		wg.Add(1)
+		go func(i int, accountKey int64) {
+			defer wg.Done()
+			assetsWitness[i], errs[i] = w.constructAccountAssetsWitness(accountKey, proverAccounts[i])
+		}(i, accountKey)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return assetsWitness, nil
+}
+
+// constructAccountAssetsWitness collects the asset merkle proofs of the account and updates its asset tree,
+// proverAccountInfo is nil for the account just registered.
+func (w *WitnessHelper) constructAccountAssetsWitness(accountKey int64, proverAccountInfo *AccountWitnessInfo) (
+	*accountAssetsWitness, error) {
+	if accountKey < 0 || accountKey >= int64(len(*w.assetTrees)) {
+		return nil, fmt.Errorf("invalid account key %d", accountKey)
+	}
+	assetTree := (*w.assetTrees)[accountKey]
+	witness := &accountAssetsWitness{}
+	assetCount := 0
+	if proverAccountInfo == nil {
+		witness.cryptoAccount = std.EmptyAccount(accountKey, tree.NilAccountAssetRoot)
+	} else {
+		pk, err := common2.ParsePubKey(proverAccountInfo.AccountInfo.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		witness.cryptoAccount = &CryptoAccount{
+			AccountIndex:    accountKey,
+			AccountNameHash: common.FromHex(proverAccountInfo.AccountInfo.AccountNameHash),
+			AccountPk:       pk,
+			Nonce:           proverAccountInfo.AccountInfo.Nonce,
+			CollectionNonce: proverAccountInfo.AccountInfo.CollectionNonce,
+			AssetRoot:       assetTree.Root(),
+		}
+		for i, accountAsset := range proverAccountInfo.AccountAssets {
+			assetMerkleProof, err := assetTree.GetProof(uint64(accountAsset.AssetId))
+			if err != nil {
+				return nil, err
+			}
+			// set crypto account asset
+			witness.cryptoAccount.AssetsInfo[assetCount] = &CryptoAccountAsset{
+				AssetId:                  accountAsset.AssetId,
+				Balance:                  accountAsset.Balance,
+				LpAmount:                 accountAsset.LpAmount,
+				OfferCanceledOrFinalized: accountAsset.OfferCanceledOrFinalized,
+			}
+
+			// set merkle proof
+			witness.merkleProofsAccountAssetsBefore[assetCount], err = SetFixedAccountAssetArray(assetMerkleProof)
+			if err != nil {
+				return nil, err
+			}
+			// update asset merkle tree
+			nBalance, err := chain.ComputeNewBalance(
+				proverAccountInfo.AssetsRelatedTxDetails[i].AssetType,
+				proverAccountInfo.AssetsRelatedTxDetails[i].Balance,
+				proverAccountInfo.AssetsRelatedTxDetails[i].BalanceDelta,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nAsset, err := types.ParseAccountAsset(nBalance)
+			if err != nil {
+				return nil, err
+			}
+			nAssetHash, err := tree.ComputeAccountAssetLeafHash(nAsset.Balance.String(), nAsset.LpAmount.String(), nAsset.OfferCanceledOrFinalized.String())
+			if err != nil {
+				return nil, err
+			}
+			err = assetTree.Set(uint64(accountAsset.AssetId), nAssetHash)
+			if err != nil {
+				return nil, err
+			}
+
+			assetCount++
+		}
+	}
+	// padding empty account asset
+	for assetCount < NbAccountAssetsPerAccount {
+		witness.cryptoAccount.AssetsInfo[assetCount] = std.EmptyAccountAsset(LastAccountAssetId)
+		assetMerkleProof, err := assetTree.GetProof(LastAccountAssetId)
+		if err != nil {
+			return nil, err
+		}
+		witness.merkleProofsAccountAssetsBefore[assetCount], err = SetFixedAccountAssetArray(assetMerkleProof)
+		if err != nil {
+			return nil, err
+		}
+		assetCount++
+	}
+	witness.assetRootAfter = assetTree.Root()
+	return witness, nil
+}
+
 func (w *WitnessHelper) constructLiquidityWitness(
 	proverLiquidityInfo *LiquidityWitnessInfo,
 ) (
@@ -0,0 +1,170 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prove
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	cryptoBlock "github.com/bnb-chain/zkbnb-crypto/legend/circuit/bn254/block"
+	"github.com/bnb-chain/zkbnb/common/storage"
+	"github.com/bnb-chain/zkbnb/dao/blockwitness"
+)
+
+const witnessKeyPrefix = "witness"
+
+// WitnessStore encodes the block witnesses and keeps them in the database, or in a blob storage
+// addressed by their hashes with only the hashes in the database.
+type WitnessStore struct {
+	storage storage.Storage
+}
+
+// NewWitnessStore returns a store which keeps the witnesses in the database if the storage type is empty.
+func NewWitnessStore(c storage.Config) (*WitnessStore, error) {
+	if c.Type == "" {
+		return &WitnessStore{}, nil
+	}
+	s, err := storage.NewStorage(c)
+	if err != nil {
+		return nil, err
+	}
+	return &WitnessStore{storage: s}, nil
+}
+
+// EncodeBlockWitness compresses the json of the witness, json is kept so that the witness is
+// decoded exactly as it was constructed.
+func EncodeBlockWitness(b *cryptoBlock.Block) ([]byte, error) {
+	bz, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(bz); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func DecodeBlockWitness(data []byte) (*cryptoBlock.Block, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	bz, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var b *cryptoBlock.Block
+	err = json.Unmarshal(bz, &b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Save encodes the witness into the block witness, the witness data is written to the blob storage
+// before the block witness is saved to the database.
+func (s *WitnessStore) Save(witness *blockwitness.BlockWitness, b *cryptoBlock.Block) error {
+	data, err := EncodeBlockWitness(b)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(data)
+	witness.Encoding = blockwitness.EncodingGzipJson
+	witness.WitnessHash = hex.EncodeToString(hash[:])
+	witness.WitnessData = ""
+	witness.WitnessBlob = nil
+	if s.storage == nil {
+		witness.WitnessBlob = data
+		return nil
+	}
+	// The witness is addressed by its content, so the same witness is only written once.
+	key := witnessKey(witness.WitnessHash)
+	exists, err := s.storage.Exists(key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return s.storage.Put(key, data)
+}
+
+// LoadEncoded returns the encoded witness of the block witness in any encoding.
+func (s *WitnessStore) LoadEncoded(witness *blockwitness.BlockWitness) ([]byte, error) {
+	switch witness.Encoding {
+	case blockwitness.EncodingJson:
+		var b *cryptoBlock.Block
+		err := json.Unmarshal([]byte(witness.WitnessData), &b)
+		if err != nil {
+			return nil, err
+		}
+		return EncodeBlockWitness(b)
+	case blockwitness.EncodingGzipJson:
+		data := witness.WitnessBlob
+		if len(data) == 0 {
+			if s.storage == nil {
+				return nil, fmt.Errorf("witness of block %d is in the blob storage which is not configured", witness.Height)
+			}
+			var err error
+			data, err = s.storage.Get(witnessKey(witness.WitnessHash))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get witness of block %d, err: %v", witness.Height, err)
+			}
+		}
+		hash := sha256.Sum256(data)
+		if hex.EncodeToString(hash[:]) != witness.WitnessHash {
+			return nil, fmt.Errorf("witness of block %d is corrupted", witness.Height)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unknown witness encoding %d", witness.Encoding)
+	}
+}
+
+func (s *WitnessStore) Load(witness *blockwitness.BlockWitness) (*cryptoBlock.Block, error) {
+	if witness.Encoding == blockwitness.EncodingJson {
+		var b *cryptoBlock.Block
+		err := json.Unmarshal([]byte(witness.WitnessData), &b)
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
+	}
+	data, err := s.LoadEncoded(witness)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeBlockWitness(data)
+}
+
+func witnessKey(hash string) string {
+	if len(hash) < 2 {
+		return witnessKeyPrefix + "/" + hash
+	}
+	return witnessKeyPrefix + "/" + hash[:2] + "/" + hash
+}
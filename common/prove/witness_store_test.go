@@ -0,0 +1,96 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prove
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cryptoBlock "github.com/bnb-chain/zkbnb-crypto/legend/circuit/bn254/block"
+	"github.com/bnb-chain/zkbnb/common/storage"
+	"github.com/bnb-chain/zkbnb/dao/blockwitness"
+)
+
+func testWitnessBlock() *cryptoBlock.Block {
+	return &cryptoBlock.Block{
+		BlockNumber:     1,
+		CreatedAt:       1660000000000,
+		OldStateRoot:    []byte{0, 1, 2},
+		NewStateRoot:    []byte{3, 4, 5},
+		BlockCommitment: []byte{6, 7, 8},
+		Txs:             []*cryptoBlock.Tx{cryptoBlock.EmptyTx(), cryptoBlock.EmptyTx()},
+	}
+}
+
+func TestWitnessStore(t *testing.T) {
+	b := testWitnessBlock()
+	expected, err := json.Marshal(b)
+	assert.NoError(t, err)
+
+	dbStore, err := NewWitnessStore(storage.Config{})
+	assert.NoError(t, err)
+	localStore, err := NewWitnessStore(storage.Config{Type: storage.LocalStorageType, LocalPath: t.TempDir()})
+	assert.NoError(t, err)
+
+	for _, s := range []*WitnessStore{dbStore, localStore} {
+		witness := &blockwitness.BlockWitness{Height: 1}
+		assert.NoError(t, s.Save(witness, b))
+		assert.Equal(t, int64(blockwitness.EncodingGzipJson), witness.Encoding)
+		assert.Empty(t, witness.WitnessData)
+		assert.Equal(t, s.storage == nil, len(witness.WitnessBlob) > 0)
+
+		loaded, err := s.Load(witness)
+		assert.NoError(t, err)
+		actual, err := json.Marshal(loaded)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual))
+
+		// The same witness is saved again without error.
+		assert.NoError(t, s.Save(&blockwitness.BlockWitness{Height: 1}, b))
+
+		witness.WitnessHash = "00" + witness.WitnessHash[2:]
+		_, err = s.LoadEncoded(witness)
+		assert.Error(t, err)
+	}
+
+	// Witnesses in the blob storage can't be loaded without the storage.
+	witness := &blockwitness.BlockWitness{Height: 1}
+	assert.NoError(t, localStore.Save(witness, b))
+	_, err = dbStore.Load(witness)
+	assert.Error(t, err)
+}
+
+func TestWitnessStoreLegacyJson(t *testing.T) {
+	b := testWitnessBlock()
+	bz, err := json.Marshal(b)
+	assert.NoError(t, err)
+
+	s, err := NewWitnessStore(storage.Config{})
+	assert.NoError(t, err)
+	witness := &blockwitness.BlockWitness{Height: 1, WitnessData: string(bz), Encoding: blockwitness.EncodingJson}
+
+	data, err := s.LoadEncoded(witness)
+	assert.NoError(t, err)
+	decoded, err := DecodeBlockWitness(data)
+	assert.NoError(t, err)
+	actual, err := json.Marshal(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, string(bz), string(actual))
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	_, err = s.Get("a/b")
+	assert.Equal(t, ErrNotFound, err)
+	exists, err := s.Exists("a/b")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, s.Put("a/b", []byte("data")))
+	data, err := s.Get("a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+	exists, err = s.Exists("a/b")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.NoError(t, s.Put("a/b", []byte("new data")))
+	data, err = s.Get("a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("new data"), data)
+
+	assert.NoError(t, s.Delete("a/b"))
+	_, err = s.Get("a/b")
+	assert.Equal(t, ErrNotFound, err)
+	assert.NoError(t, s.Delete("a/b"))
+}
+
+func TestLocalStorageInvalidKey(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "/a", "../a", "a/../../b", "a//b", "a/./b"} {
+		assert.Equal(t, ErrInvalidKey, s.Put(key, []byte("data")), key)
+		_, err = s.Get(key)
+		assert.Equal(t, ErrInvalidKey, err, key)
+	}
+}
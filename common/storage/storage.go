@@ -0,0 +1,72 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	LocalStorageType = "local"
+	S3StorageType    = "s3"
+)
+
+var (
+	ErrNotFound   = errors.New("storage: object not found")
+	ErrInvalidKey = errors.New("storage: invalid key")
+)
+
+// Storage is a blob store addressed by keys, keys are slash separated paths.
+type Storage interface {
+	Get(key string) ([]byte, error)
+	Put(key string, data []byte) error
+	Exists(key string) (bool, error)
+	Delete(key string) error
+}
+
+type Config struct {
+	Type      string
+	LocalPath string   `json:",optional"`
+	S3        S3Config `json:",optional"`
+}
+
+func NewStorage(c Config) (Storage, error) {
+	switch c.Type {
+	case LocalStorageType:
+		return NewLocalStorage(c.LocalPath)
+	case S3StorageType:
+		return NewS3Storage(c.S3)
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %s", c.Type)
+	}
+}
+
+// checkKey rejects the keys which are absolute or escape from the root.
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
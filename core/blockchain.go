@@ -15,6 +15,7 @@ import (
 	"github.com/bnb-chain/zkbnb/common/chain"
 	sdb "github.com/bnb-chain/zkbnb/core/statedb"
 	"github.com/bnb-chain/zkbnb/dao/account"
+	"github.com/bnb-chain/zkbnb/dao/asset"
 	"github.com/bnb-chain/zkbnb/dao/block"
 	"github.com/bnb-chain/zkbnb/dao/compressedblock"
 	"github.com/bnb-chain/zkbnb/dao/dbcache"
@@ -48,6 +49,11 @@ type BlockChain struct {
 
 	currentBlock *block.Block
 	processor    Processor
+
+	// Status of the assets by asset id. The monitor updates the asset status in the db at any
+	// time, so the status is loaded once for each block and reset in ProposeNewBlock, all txs
+	// of a block are verified against the same snapshot.
+	assetStatus map[int64]uint32
 }
 
 func NewBlockChain(config *ChainConfig, moduleName string) (*BlockChain, error) {
@@ -92,12 +98,14 @@ func NewBlockChain(config *ChainConfig, moduleName string) (*BlockChain, error)
 // NewBlockChainForDryRun - for dry run mode, we can reuse existing models for quick creation
 // , e.g., for sending tx, we can create blockchain for each request quickly
 func NewBlockChainForDryRun(accountModel account.AccountModel, liquidityModel liquidity.LiquidityModel,
-	nftModel nft.L2NftModel, mempoolModel mempool.MempoolModel, redisCache dbcache.Cache) *BlockChain {
+	nftModel nft.L2NftModel, mempoolModel mempool.MempoolModel, assetModel asset.AssetModel,
+	redisCache dbcache.Cache) *BlockChain {
 	chainDb := &sdb.ChainDB{
-		AccountModel:   accountModel,
-		LiquidityModel: liquidityModel,
-		L2NftModel:     nftModel,
-		MempoolModel:   mempoolModel,
+		AccountModel:     accountModel,
+		LiquidityModel:   liquidityModel,
+		L2NftModel:       nftModel,
+		MempoolModel:     mempoolModel,
+		L2AssetInfoModel: assetModel,
 	}
 	bc := &BlockChain{
 		ChainDB: chainDb,
@@ -124,6 +132,7 @@ func (bc *BlockChain) ProposeNewBlock() (*block.Block, error) {
 
 	bc.currentBlock = newBlock
 	bc.Statedb.PurgeCache(bc.currentBlock.StateRoot)
+	bc.assetStatus = nil
 	return newBlock, nil
 }
 
@@ -170,6 +179,8 @@ func (bc *BlockChain) CommitNewBlock(blockSize int, createdAt int64) (*block.Blo
 		PendingNewNft:              pendingNewNft,
 		PendingUpdateNft:           pendingUpdateNft,
 		PendingNewNftHistory:       pendingNewNftHistory,
+		PendingUpdateOffer:         bc.Statedb.PendingUpdateOffers,
+		PendingNewRoyalty:          bc.Statedb.PendingNewRoyalties,
 	}, nil
 }
 
@@ -264,6 +275,30 @@ func (bc *BlockChain) VerifyNonce(accountIndex int64, nonce int64) error {
 	return nil
 }
 
+// VerifyAssetStatus checks that the assets are not paused by the governance. The executors
+// must not read the asset status from the db directly, the snapshot of the current block is
+// the source of truth, a pause synced by the monitor takes effect from the next block.
+func (bc *BlockChain) VerifyAssetStatus(assetIds ...int64) error {
+	if bc.assetStatus == nil {
+		bc.assetStatus = make(map[int64]uint32)
+	}
+	for _, assetId := range assetIds {
+		status, ok := bc.assetStatus[assetId]
+		if !ok {
+			l2Asset, err := bc.L2AssetInfoModel.GetAssetById(assetId)
+			if err != nil {
+				return fmt.Errorf("failed to get asset %d, err: %v", assetId, err)
+			}
+			status = l2Asset.Status
+			bc.assetStatus[assetId] = status
+		}
+		if status != asset.StatusActive {
+			return fmt.Errorf("asset %d is paused", assetId)
+		}
+	}
+	return nil
+}
+
 func (bc *BlockChain) StateDB() *sdb.StateDB {
 	return bc.Statedb
 }
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+	"github.com/bnb-chain/zkbnb-crypto/wasm/legend/legendTxTypes"
+	"github.com/bnb-chain/zkbnb/core/executor"
+	sdb "github.com/bnb-chain/zkbnb/core/statedb"
+	"github.com/bnb-chain/zkbnb/dao/asset"
+	"github.com/bnb-chain/zkbnb/dao/block"
+	"github.com/bnb-chain/zkbnb/dao/tx"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+// testAssetModel serves the asset status from memory, it is changed by the tests like the monitor does.
+type testAssetModel struct {
+	asset.AssetModel
+	status map[int64]uint32
+	loads  int
+}
+
+func (m *testAssetModel) GetAssetById(assetId int64) (*asset.Asset, error) {
+	m.loads++
+	return &asset.Asset{AssetId: uint32(assetId), Status: m.status[assetId]}, nil
+}
+
+func newTestBlockChain(assetModel asset.AssetModel, accounts ...*types.AccountInfo) *BlockChain {
+	chainDb := &sdb.ChainDB{L2AssetInfoModel: assetModel}
+	bc := &BlockChain{
+		ChainDB:      chainDb,
+		Statedb:      sdb.NewStateDBForDryRun(nil, chainDb),
+		currentBlock: &block.Block{BlockHeight: 1},
+	}
+	for _, account := range accounts {
+		bc.Statedb.AccountMap[account.AccountIndex] = account
+	}
+	return bc
+}
+
+func newTestAccount(index int64, publicKey string, assetIds ...int64) *types.AccountInfo {
+	assetInfo := make(map[int64]*types.AccountAsset)
+	for _, assetId := range assetIds {
+		assetInfo[assetId] = &types.AccountAsset{
+			AssetId:                  assetId,
+			Balance:                  big.NewInt(1000000),
+			LpAmount:                 big.NewInt(0),
+			OfferCanceledOrFinalized: big.NewInt(0),
+		}
+	}
+	return &types.AccountInfo{
+		AccountIndex:    index,
+		AccountName:     fmt.Sprintf("account%d.legend", index),
+		AccountNameHash: fmt.Sprintf("0x%064x", index),
+		PublicKey:       publicKey,
+		AssetInfo:       assetInfo,
+	}
+}
+
+func newTestTransferTx(t *testing.T, sk *curve.PrivateKey, assetId, gasFeeAssetId int64) *tx.Tx {
+	segment, err := json.Marshal(&legendTxTypes.TransferSegmentFormat{
+		FromAccountIndex:  2,
+		ToAccountIndex:    3,
+		ToAccountNameHash: fmt.Sprintf("0x%064x", 3),
+		AssetId:           assetId,
+		AssetAmount:       "100",
+		GasAccountIndex:   1,
+		GasFeeAssetId:     gasFeeAssetId,
+		GasFeeAssetAmount: "10",
+		ExpiredAt:         time.Now().Add(time.Hour).UnixMilli(),
+		Nonce:             0,
+	})
+	assert.NoError(t, err)
+	txInfo, err := legendTxTypes.ConstructTransferTxInfo(sk, string(segment))
+	assert.NoError(t, err)
+	txInfoBytes, err := json.Marshal(txInfo)
+	assert.NoError(t, err)
+	return &tx.Tx{TxType: types.TxTypeTransfer, TxInfo: string(txInfoBytes)}
+}
+
+func TestTransferWithPausedAsset(t *testing.T) {
+	sk, err := curve.GenerateEddsaPrivateKey("seed")
+	assert.NoError(t, err)
+	publicKey := hex.EncodeToString(sk.PublicKey.Bytes())
+
+	assetModel := &testAssetModel{status: map[int64]uint32{
+		0: asset.StatusActive,
+		1: asset.StatusInactive,
+	}}
+	bc := newTestBlockChain(assetModel,
+		newTestAccount(1, publicKey, 0, 1),
+		newTestAccount(2, publicKey, 0, 1),
+		newTestAccount(3, publicKey, 0, 1),
+	)
+	verifyTransfer := func(assetId, gasFeeAssetId int64) error {
+		e, err := executor.NewTxExecutor(bc, newTestTransferTx(t, sk, assetId, gasFeeAssetId))
+		assert.NoError(t, err)
+		return e.VerifyInputs()
+	}
+
+	assert.NoError(t, verifyTransfer(0, 0))
+	assert.EqualError(t, verifyTransfer(1, 0), "asset 1 is paused")
+	assert.EqualError(t, verifyTransfer(0, 1), "asset 1 is paused")
+
+	// The asset is resumed in the db, but the txs of the current block still see the snapshot.
+	assetModel.status[1] = asset.StatusActive
+	assert.EqualError(t, verifyTransfer(1, 0), "asset 1 is paused")
+	assert.Equal(t, 2, assetModel.loads)
+
+	// The status is loaded again for the next block.
+	_, err = bc.ProposeNewBlock()
+	assert.NoError(t, err)
+	assert.NoError(t, verifyTransfer(1, 0))
+	assert.Equal(t, 4, assetModel.loads)
+
+	// Pausing the asset in the middle of a block does not affect the txs of the block either.
+	assetModel.status[1] = asset.StatusInactive
+	assert.NoError(t, verifyTransfer(1, 1))
+	_, err = bc.ProposeNewBlock()
+	assert.NoError(t, err)
+	assert.EqualError(t, verifyTransfer(1, 0), "asset 1 is paused")
+}
@@ -83,6 +83,10 @@ func (e *AddLiquidityExecutor) VerifyInputs() error {
 	if err != nil {
 		return err
 	}
+	err = e.bc.VerifyAssetStatus(txInfo.AssetAId, txInfo.AssetBId, txInfo.GasFeeAssetId)
+	if err != nil {
+		return err
+	}
 
 	fromAccount := bc.StateDB().AccountMap[txInfo.FromAccountIndex]
 	if txInfo.GasFeeAssetId == txInfo.AssetAId {
@@ -412,7 +416,7 @@ func (e *AddLiquidityExecutor) GenerateTxDetails() ([]*tx.TxDetail, error) {
 	poolLp := ffmath.Sub(liquidityInfo.LpAmount, txInfo.TreasuryAmount)
 	var lpDeltaForFromAccount *big.Int
 	if liquidityInfo.AssetA.Cmp(types.ZeroBigInt) == 0 {
-		lpDeltaForFromAccount, err = common2.CleanPackedAmount(new(big.Int).Sqrt(ffmath.Multiply(txInfo.AssetAAmount, txInfo.AssetBAmount)))
+		lpDeltaForFromAccount, err = chain.ComputeEmptyLpAmount(txInfo.AssetAAmount, txInfo.AssetBAmount)
 		if err != nil {
 			logx.Errorf("unable to compute lp delta: %s", err.Error())
 			return nil, err
@@ -15,6 +15,8 @@ import (
 	common2 "github.com/bnb-chain/zkbnb/common"
 	"github.com/bnb-chain/zkbnb/core/statedb"
 	"github.com/bnb-chain/zkbnb/dao/mempool"
+	"github.com/bnb-chain/zkbnb/dao/offer"
+	"github.com/bnb-chain/zkbnb/dao/royalty"
 	"github.com/bnb-chain/zkbnb/dao/tx"
 	"github.com/bnb-chain/zkbnb/types"
 )
@@ -99,6 +101,10 @@ func (e *AtomicMatchExecutor) VerifyInputs() error {
 	if err != nil {
 		return err
 	}
+	err = e.bc.VerifyAssetStatus(txInfo.BuyOffer.AssetId, txInfo.GasFeeAssetId)
+	if err != nil {
+		return err
+	}
 
 	if txInfo.BuyOffer.Type != types.BuyOfferType ||
 		txInfo.SellOffer.Type != types.SellOfferType {
@@ -208,6 +214,29 @@ func (e *AtomicMatchExecutor) ApplyTransaction() error {
 	stateCache.PendingUpdateAccountIndexMap[matchNft.CreatorAccountIndex] = statedb.StateCachePending
 	stateCache.PendingUpdateAccountIndexMap[txInfo.GasAccountIndex] = statedb.StateCachePending
 	stateCache.PendingUpdateNftIndexMap[txInfo.SellOffer.NftIndex] = statedb.StateCachePending
+	stateCache.PendingUpdateOffers = append(stateCache.PendingUpdateOffers, &offer.Offer{
+		AccountIndex: txInfo.BuyOffer.AccountIndex,
+		OfferId:      txInfo.BuyOffer.OfferId,
+		Status:       offer.StatusFinalized,
+		TxHash:       e.tx.TxHash,
+	}, &offer.Offer{
+		AccountIndex: txInfo.SellOffer.AccountIndex,
+		OfferId:      txInfo.SellOffer.OfferId,
+		Status:       offer.StatusFinalized,
+		TxHash:       e.tx.TxHash,
+	})
+	if txInfo.CreatorAmount.Cmp(big.NewInt(0)) > 0 {
+		stateCache.PendingNewRoyalties = append(stateCache.PendingNewRoyalties, &royalty.Royalty{
+			TxHash:              e.tx.TxHash,
+			CreatorAccountIndex: matchNft.CreatorAccountIndex,
+			CollectionId:        matchNft.CollectionId,
+			NftIndex:            matchNft.NftIndex,
+			AssetId:             txInfo.BuyOffer.AssetId,
+			Amount:              txInfo.CreatorAmount.String(),
+			BlockHeight:         bc.CurrentBlock().BlockHeight,
+			BlockTime:           bc.CurrentBlock().CreatedAt,
+		})
+	}
 
 	return nil
 }
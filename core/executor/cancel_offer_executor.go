@@ -15,6 +15,7 @@ import (
 	common2 "github.com/bnb-chain/zkbnb/common"
 	"github.com/bnb-chain/zkbnb/core/statedb"
 	"github.com/bnb-chain/zkbnb/dao/mempool"
+	"github.com/bnb-chain/zkbnb/dao/offer"
 	"github.com/bnb-chain/zkbnb/dao/tx"
 	"github.com/bnb-chain/zkbnb/types"
 )
@@ -64,6 +65,10 @@ func (e *CancelOfferExecutor) VerifyInputs() error {
 	if err != nil {
 		return err
 	}
+	err = e.bc.VerifyAssetStatus(txInfo.GasFeeAssetId)
+	if err != nil {
+		return err
+	}
 
 	fromAccount := e.bc.StateDB().AccountMap[txInfo.AccountIndex]
 	if fromAccount.AssetInfo[txInfo.GasFeeAssetId].Balance.Cmp(txInfo.GasFeeAssetAmount) < 0 {
@@ -104,6 +109,12 @@ func (e *CancelOfferExecutor) ApplyTransaction() error {
 	stateCache := e.bc.StateDB()
 	stateCache.PendingUpdateAccountIndexMap[txInfo.AccountIndex] = statedb.StateCachePending
 	stateCache.PendingUpdateAccountIndexMap[txInfo.GasAccountIndex] = statedb.StateCachePending
+	stateCache.PendingUpdateOffers = append(stateCache.PendingUpdateOffers, &offer.Offer{
+		AccountIndex: txInfo.AccountIndex,
+		OfferId:      txInfo.OfferId,
+		Status:       offer.StatusCanceled,
+		TxHash:       e.tx.TxHash,
+	})
 
 	return nil
 }
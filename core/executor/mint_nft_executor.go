@@ -68,6 +68,10 @@ func (e *MintNftExecutor) VerifyInputs() error {
 	if err != nil {
 		return err
 	}
+	err = e.bc.VerifyAssetStatus(txInfo.GasFeeAssetId)
+	if err != nil {
+		return err
+	}
 
 	creatorAccount := e.bc.StateDB().AccountMap[txInfo.CreatorAccountIndex]
 	if creatorAccount.CollectionNonce <= txInfo.NftCollectionId {
@@ -81,6 +81,10 @@ func (e *RemoveLiquidityExecutor) VerifyInputs() error {
 	if err != nil {
 		return err
 	}
+	err = e.bc.VerifyAssetStatus(txInfo.AssetAId, txInfo.AssetBId, txInfo.GasFeeAssetId)
+	if err != nil {
+		return err
+	}
 
 	fromAccount := bc.StateDB().AccountMap[txInfo.FromAccountIndex]
 	if fromAccount.AssetInfo[txInfo.GasFeeAssetId].Balance.Cmp(txInfo.GasFeeAssetAmount) < 0 {
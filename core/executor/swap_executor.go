@@ -80,6 +80,10 @@ func (e *SwapExecutor) VerifyInputs() error {
 	if err != nil {
 		return err
 	}
+	err = e.bc.VerifyAssetStatus(txInfo.AssetAId, txInfo.AssetBId, txInfo.GasFeeAssetId)
+	if err != nil {
+		return err
+	}
 
 	fromAccount := bc.StateDB().AccountMap[txInfo.FromAccountIndex]
 	if txInfo.GasFeeAssetId != txInfo.AssetAId {
@@ -63,6 +63,10 @@ func (e *TransferExecutor) VerifyInputs() error {
 	if err != nil {
 		return err
 	}
+	err = e.bc.VerifyAssetStatus(txInfo.AssetId, txInfo.GasFeeAssetId)
+	if err != nil {
+		return err
+	}
 
 	fromAccount := bc.StateDB().AccountMap[txInfo.FromAccountIndex]
 	toAccount := bc.StateDB().AccountMap[txInfo.ToAccountIndex]
@@ -69,6 +69,10 @@ func (e *TransferNftExecutor) VerifyInputs() error {
 	if err != nil {
 		return err
 	}
+	err = e.bc.VerifyAssetStatus(txInfo.GasFeeAssetId)
+	if err != nil {
+		return err
+	}
 
 	fromAccount := e.bc.StateDB().AccountMap[txInfo.FromAccountIndex]
 	if fromAccount.AssetInfo[txInfo.GasFeeAssetId].Balance.Cmp(txInfo.GasFeeAssetAmount) < 0 {
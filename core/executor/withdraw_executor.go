@@ -63,6 +63,10 @@ func (e *WithdrawExecutor) VerifyInputs() error {
 	if err != nil {
 		return err
 	}
+	err = e.bc.VerifyAssetStatus(txInfo.AssetId, txInfo.GasFeeAssetId)
+	if err != nil {
+		return err
+	}
 
 	fromAccount := e.bc.StateDB().AccountMap[txInfo.FromAccountIndex]
 	if txInfo.GasFeeAssetId != txInfo.AssetId {
@@ -82,6 +82,10 @@ func (e *WithdrawNftExecutor) VerifyInputs() error {
 	if err != nil {
 		return err
 	}
+	err = e.bc.VerifyAssetStatus(txInfo.GasFeeAssetId)
+	if err != nil {
+		return err
+	}
 
 	fromAccount := e.bc.StateDB().AccountMap[txInfo.AccountIndex]
 	if fromAccount.AssetInfo[txInfo.GasFeeAssetId].Balance.Cmp(txInfo.GasFeeAssetAmount) < 0 {
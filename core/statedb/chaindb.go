@@ -10,6 +10,9 @@ import (
 	"github.com/bnb-chain/zkbnb/dao/liquidity"
 	"github.com/bnb-chain/zkbnb/dao/mempool"
 	"github.com/bnb-chain/zkbnb/dao/nft"
+	"github.com/bnb-chain/zkbnb/dao/offer"
+	"github.com/bnb-chain/zkbnb/dao/royalty"
+	"github.com/bnb-chain/zkbnb/dao/sysconfig"
 	"github.com/bnb-chain/zkbnb/dao/tx"
 )
 
@@ -29,6 +32,11 @@ type ChainDB struct {
 	L2NftModel            nft.L2NftModel
 	L2NftHistoryModel     nft.L2NftHistoryModel
 	MempoolModel          mempool.MempoolModel
+	OfferModel            offer.OfferModel
+	RoyaltyModel          royalty.RoyaltyModel
+
+	// Sys config
+	SysConfigModel sysconfig.SysConfigModel
 }
 
 func NewChainDB(db *gorm.DB) *ChainDB {
@@ -46,5 +54,9 @@ func NewChainDB(db *gorm.DB) *ChainDB {
 		L2NftModel:            nft.NewL2NftModel(db),
 		L2NftHistoryModel:     nft.NewL2NftHistoryModel(db),
 		MempoolModel:          mempool.NewMempoolModel(db),
+		OfferModel:            offer.NewOfferModel(db),
+		RoyaltyModel:          royalty.NewRoyaltyModel(db),
+
+		SysConfigModel: sysconfig.NewSysConfigModel(db),
 	}
 }
@@ -4,6 +4,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/bnb-chain/zkbnb-crypto/legend/circuit/bn254/std"
+	"github.com/bnb-chain/zkbnb/dao/offer"
+	"github.com/bnb-chain/zkbnb/dao/royalty"
 	"github.com/bnb-chain/zkbnb/dao/tx"
 	"github.com/bnb-chain/zkbnb/types"
 )
@@ -31,6 +33,8 @@ type StateCache struct {
 	PendingUpdateAccountIndexMap   map[int64]int
 	PendingUpdateLiquidityIndexMap map[int64]int
 	PendingUpdateNftIndexMap       map[int64]int
+	PendingUpdateOffers            []*offer.Offer
+	PendingNewRoyalties            []*royalty.Royalty
 }
 
 func NewStateCache(stateRoot string) *StateCache {
@@ -44,6 +48,8 @@ func NewStateCache(stateRoot string) *StateCache {
 		PendingUpdateAccountIndexMap:   make(map[int64]int, 0),
 		PendingUpdateLiquidityIndexMap: make(map[int64]int, 0),
 		PendingUpdateNftIndexMap:       make(map[int64]int, 0),
+		PendingUpdateOffers:            make([]*offer.Offer, 0),
+		PendingNewRoyalties:            make([]*royalty.Royalty, 0),
 
 		PubData:                         make([]byte, 0),
 		PriorityOperations:              0,
@@ -46,6 +46,7 @@ type (
 		GetMaxAssetId() (max int64, err error)
 		CreateAssetsInTransact(tx *gorm.DB, assets []*Asset) error
 		UpdateAssetsInTransact(tx *gorm.DB, assets []*Asset) error
+		DeleteAssetsInTransact(tx *gorm.DB, assets []*Asset) error
 	}
 
 	defaultAssetModel struct {
@@ -139,7 +140,7 @@ func (m *defaultAssetModel) GetAssetBySymbol(symbol string) (res *Asset, err err
 }
 
 func (m *defaultAssetModel) GetAssetByAddress(address string) (asset *Asset, err error) {
-	dbTx := m.DB.Table(m.table).Where("asset_address = ?", address).Find(&asset)
+	dbTx := m.DB.Table(m.table).Where("l1_address = ?", address).Find(&asset)
 	if dbTx.Error != nil {
 		return nil, types.DbErrSqlOperation
 	} else if dbTx.RowsAffected == 0 {
@@ -181,7 +182,21 @@ func (m *defaultAssetModel) CreateAssetsInTransact(tx *gorm.DB, assets []*Asset)
 
 func (m *defaultAssetModel) UpdateAssetsInTransact(tx *gorm.DB, assets []*Asset) error {
 	for _, asset := range assets {
-		dbTx := tx.Table(m.table).Where("id = ?", asset.ID).Delete(&asset)
+		dbTx := tx.Table(m.table).Where("id = ?", asset.ID).Select("*").Updates(&asset)
+		if dbTx.Error != nil {
+			return dbTx.Error
+		}
+		if dbTx.RowsAffected == 0 {
+			return types.DbErrFailToUpdateAsset
+		}
+	}
+	return nil
+}
+
+// DeleteAssetsInTransact deletes the assets permanently, so that the asset ids can be registered again.
+func (m *defaultAssetModel) DeleteAssetsInTransact(tx *gorm.DB, assets []*Asset) error {
+	for _, asset := range assets {
+		dbTx := tx.Table(m.table).Unscoped().Where("id = ?", asset.ID).Delete(&Asset{})
 		if dbTx.Error != nil {
 			return dbTx.Error
 		}
@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package auditlog
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	TableName = `audit_log`
+)
+
+type (
+	AuditLogModel interface {
+		CreateAuditLogTable() error
+		DropAuditLogTable() error
+		CreateAuditLog(log *AuditLog) error
+		GetAuditLogsTotalCount() (count int64, err error)
+		GetAuditLogs(limit int64, offset int64) (logs []*AuditLog, err error)
+	}
+
+	defaultAuditLogModel struct {
+		table string
+		DB    *gorm.DB
+	}
+
+	// AuditLog records an operation of the admin api.
+	AuditLog struct {
+		gorm.Model
+		// name of the api key which is used
+		Operator string `gorm:"index"`
+		Action   string
+		// request params in json
+		Params string
+		// empty if the operation succeeds
+		Error string
+	}
+)
+
+func NewAuditLogModel(db *gorm.DB) AuditLogModel {
+	return &defaultAuditLogModel{
+		table: TableName,
+		DB:    db,
+	}
+}
+
+func (*AuditLog) TableName() string {
+	return TableName
+}
+
+func (m *defaultAuditLogModel) CreateAuditLogTable() error {
+	return m.DB.AutoMigrate(AuditLog{})
+}
+
+func (m *defaultAuditLogModel) DropAuditLogTable() error {
+	return m.DB.Migrator().DropTable(m.table)
+}
+
+func (m *defaultAuditLogModel) CreateAuditLog(log *AuditLog) error {
+	dbTx := m.DB.Table(m.table).Create(log)
+	if dbTx.Error != nil {
+		return types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return types.DbErrFailToCreateAuditLog
+	}
+	return nil
+}
+
+func (m *defaultAuditLogModel) GetAuditLogsTotalCount() (count int64, err error) {
+	dbTx := m.DB.Table(m.table).Where("deleted_at is NULL").Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	}
+	return count, nil
+}
+
+// GetAuditLogs returns the audit logs, the latest one first.
+func (m *defaultAuditLogModel) GetAuditLogs(limit int64, offset int64) (logs []*AuditLog, err error) {
+	dbTx := m.DB.Table(m.table).Limit(int(limit)).Offset(int(offset)).Order("id desc").Find(&logs)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return logs, nil
+}
@@ -19,7 +19,9 @@ package block
 
 import (
 	"errors"
+	"fmt"
 	"sort"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -27,6 +29,8 @@ import (
 	"github.com/bnb-chain/zkbnb/dao/compressedblock"
 	"github.com/bnb-chain/zkbnb/dao/liquidity"
 	"github.com/bnb-chain/zkbnb/dao/nft"
+	"github.com/bnb-chain/zkbnb/dao/offer"
+	"github.com/bnb-chain/zkbnb/dao/royalty"
 	"github.com/bnb-chain/zkbnb/dao/tx"
 	"github.com/bnb-chain/zkbnb/types"
 )
@@ -53,6 +57,10 @@ type (
 		GetBlockByHeightWithoutTx(blockHeight int64) (block *Block, err error)
 		GetCommittedBlocksCount() (count int64, err error)
 		GetVerifiedBlocksCount() (count int64, err error)
+		GetBlocksCountBetween(from, to time.Time) (count int64, err error)
+		GetCommittedBlocksCountBetween(from, to time.Time) (count int64, err error)
+		GetVerifiedBlocksCountBetween(from, to time.Time) (count int64, err error)
+		GetOldestBlockByStatus(status int64) (block *Block, err error)
 		GetLatestVerifiedHeight() (height int64, err error)
 		GetBlockByCommitment(blockCommitment string) (block *Block, err error)
 		GetCommittedBlocksBetween(start, end int64) (blocks []*Block, err error)
@@ -62,6 +70,8 @@ type (
 		CreateNewBlock(oBlock *Block) (err error)
 		UpdateBlocksWithoutTxsInTransact(tx *gorm.DB, blocks []*Block) (err error)
 		UpdateBlockInTransact(tx *gorm.DB, block *Block) (err error)
+		RevertBlocksInTransact(tx *gorm.DB, committedHeight int64) (err error)
+		RevertBlocksByL1TxHashesInTransact(tx *gorm.DB, committedTxHashes, verifiedTxHashes []string) (err error)
 	}
 
 	defaultBlockModel struct {
@@ -80,9 +90,9 @@ type (
 		PendingOnChainOperationsHash    string
 		PendingOnChainOperationsPubData string
 		CommittedTxHash                 string
-		CommittedAt                     int64
+		CommittedAt                     int64 `gorm:"index"`
 		VerifiedTxHash                  string
-		VerifiedAt                      int64
+		VerifiedAt                      int64    `gorm:"index"`
 		Txs                             []*tx.Tx `gorm:"foreignKey:BlockId"`
 		BlockStatus                     int64
 	}
@@ -100,6 +110,8 @@ type (
 		PendingNewNft              []*nft.L2Nft
 		PendingUpdateNft           []*nft.L2Nft
 		PendingNewNftHistory       []*nft.L2NftHistory
+		PendingUpdateOffer         []*offer.Offer
+		PendingNewRoyalty          []*royalty.Royalty
 	}
 )
 
@@ -115,7 +127,12 @@ func (*Block) TableName() string {
 }
 
 func (m *defaultBlockModel) CreateBlockTable() error {
-	return m.DB.AutoMigrate(Block{})
+	err := m.DB.AutoMigrate(Block{})
+	if err != nil {
+		return err
+	}
+	// The blocks are counted in time windows by the pipeline status.
+	return m.DB.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_created_at ON %s (created_at)", m.table, m.table)).Error
 }
 
 func (m *defaultBlockModel) DropBlockTable() error {
@@ -381,3 +398,84 @@ func (m *defaultBlockModel) UpdateBlockInTransact(tx *gorm.DB, block *Block) (er
 	}
 	return nil
 }
+
+// RevertBlocksInTransact resets the committed blocks above the committed height on L1 to pending,
+// so that they are committed again.
+func (m *defaultBlockModel) RevertBlocksInTransact(tx *gorm.DB, committedHeight int64) (err error) {
+	dbTx := tx.Table(m.table).Where("block_height > ? AND block_status = ?", committedHeight, StatusCommitted).
+		Updates(map[string]interface{}{
+			"block_status":      StatusPending,
+			"committed_tx_hash": "",
+			"committed_at":      0,
+		})
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	return nil
+}
+
+// RevertBlocksByL1TxHashesInTransact resets the blocks which are verified or committed by the l1 txs, it's used
+// when the l1 blocks of the txs are orphaned, the statuses are set again when the txs are synced again.
+func (m *defaultBlockModel) RevertBlocksByL1TxHashesInTransact(tx *gorm.DB, committedTxHashes, verifiedTxHashes []string) (err error) {
+	if len(verifiedTxHashes) > 0 {
+		dbTx := tx.Table(m.table).Where("verified_tx_hash IN ? AND block_status = ?", verifiedTxHashes, StatusVerifiedAndExecuted).
+			Updates(map[string]interface{}{
+				"block_status":     StatusCommitted,
+				"verified_tx_hash": "",
+				"verified_at":      0,
+			})
+		if dbTx.Error != nil {
+			return dbTx.Error
+		}
+	}
+	if len(committedTxHashes) > 0 {
+		dbTx := tx.Table(m.table).Where("committed_tx_hash IN ? AND block_status = ?", committedTxHashes, StatusCommitted).
+			Updates(map[string]interface{}{
+				"block_status":      StatusPending,
+				"committed_tx_hash": "",
+				"committed_at":      0,
+			})
+		if dbTx.Error != nil {
+			return dbTx.Error
+		}
+	}
+	return nil
+}
+
+func (m *defaultBlockModel) GetBlocksCountBetween(from, to time.Time) (count int64, err error) {
+	dbTx := m.DB.Table(m.table).Where("created_at BETWEEN ? AND ? AND deleted_at is NULL", from, to).Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	}
+	return count, nil
+}
+
+// GetCommittedBlocksCountBetween counts the blocks committed in the l1 blocks with timestamps between from and to.
+func (m *defaultBlockModel) GetCommittedBlocksCountBetween(from, to time.Time) (count int64, err error) {
+	dbTx := m.DB.Table(m.table).Where("block_status >= ? AND committed_at BETWEEN ? AND ? AND deleted_at is NULL",
+		StatusCommitted, from.Unix(), to.Unix()).Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	}
+	return count, nil
+}
+
+// GetVerifiedBlocksCountBetween counts the blocks verified in the l1 blocks with timestamps between from and to.
+func (m *defaultBlockModel) GetVerifiedBlocksCountBetween(from, to time.Time) (count int64, err error) {
+	dbTx := m.DB.Table(m.table).Where("block_status = ? AND verified_at BETWEEN ? AND ? AND deleted_at is NULL",
+		StatusVerifiedAndExecuted, from.Unix(), to.Unix()).Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	}
+	return count, nil
+}
+
+func (m *defaultBlockModel) GetOldestBlockByStatus(status int64) (block *Block, err error) {
+	dbTx := m.DB.Table(m.table).Where("block_status = ?", status).Order("block_height").Limit(1).Find(&block)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return block, nil
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/bnb-chain/zkbnb/types"
 )
@@ -29,6 +30,15 @@ import (
 const (
 	StatusPublished = iota
 	StatusReceived
+	StatusProved
+)
+
+const (
+	// The witness is the raw json in WitnessData.
+	EncodingJson = iota
+	// The witness is the gzip compressed json in WitnessBlob, or in the blob storage under
+	// WitnessHash if WitnessBlob is empty.
+	EncodingGzipJson
 )
 
 const (
@@ -44,6 +54,18 @@ type (
 		UpdateBlockWitnessStatus(witness *BlockWitness, status int64) error
 		GetLatestBlockWitness() (witness *BlockWitness, err error)
 		CreateBlockWitness(witness *BlockWitness) error
+		CreateBlockWitnesses(witnesses []*BlockWitness) error
+		AcquireBlockWitnessLease(workerId string, blockSizes []int, leaseExpiredAt time.Time) (witness *BlockWitness, err error)
+		RenewBlockWitnessLease(witness *BlockWitness, leaseExpiredAt time.Time) error
+		ReleaseBlockWitnessLease(witness *BlockWitness) error
+		ReclaimExpiredBlockWitnessLeases(now time.Time) (count int64, err error)
+		GetLeasedBlockWitnesses() (witnesses []*BlockWitness, err error)
+		GetOldestUnprovedBlockWitness() (witness *BlockWitness, err error)
+		GetBlockWitnessesCountBetween(from, to time.Time) (count int64, err error)
+		RescheduleBlockWitnessInTransact(tx *gorm.DB, height int64) error
+		DeleteBlockWitnessesInTransact(tx *gorm.DB, fromHeight int64) error
+		GetBlockWitnessesByEncoding(encoding int64, limit int) (witnesses []*BlockWitness, err error)
+		UpdateBlockWitnessData(witness *BlockWitness) error
 	}
 
 	defaultBlockWitnessModel struct {
@@ -53,9 +75,19 @@ type (
 
 	BlockWitness struct {
 		gorm.Model
-		Height      int64 `gorm:"index:idx_height,unique"`
+		Height int64 `gorm:"index:idx_height,unique"`
+		// Number of txs in the witness including the padding, it decides which circuit proves it.
+		BlockSize   int64 `gorm:"index"`
 		WitnessData string
-		Status      int64
+		Encoding    int64 `gorm:"index"`
+		WitnessBlob []byte
+		// Sha256 of the encoded witness, it is the key of the witness in the blob storage.
+		WitnessHash string
+		Status      int64 `gorm:"index"`
+		// The prover worker which holds the lease of the witness, only valid in StatusReceived.
+		WorkerId       string
+		LeasedAt       time.Time
+		LeaseExpiredAt time.Time
 	}
 )
 
@@ -71,7 +103,12 @@ func (*BlockWitness) TableName() string {
 }
 
 func (m *defaultBlockWitnessModel) CreateBlockWitnessTable() error {
-	return m.DB.AutoMigrate(BlockWitness{})
+	err := m.DB.AutoMigrate(BlockWitness{})
+	if err != nil {
+		return err
+	}
+	// The witness throughput of the pipeline status counts the witnesses by created_at.
+	return m.DB.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_created_at ON %s (created_at)", m.table, m.table)).Error
 }
 
 func (m *defaultBlockWitnessModel) DropBlockWitnessTable() error {
@@ -124,6 +161,32 @@ func (m *defaultBlockWitnessModel) CreateBlockWitness(witness *BlockWitness) err
 	return nil
 }
 
+// CreateBlockWitnesses creates the witnesses of consecutive blocks in one transaction.
+func (m *defaultBlockWitnessModel) CreateBlockWitnesses(witnesses []*BlockWitness) error {
+	if len(witnesses) == 0 {
+		return nil
+	}
+	if witnesses[0].Height > 1 {
+		_, err := m.GetBlockWitnessByHeight(witnesses[0].Height - 1)
+		if err != nil {
+			return fmt.Errorf("previous witness does not exist")
+		}
+	}
+
+	return m.DB.Transaction(func(tx *gorm.DB) error {
+		for i, witness := range witnesses {
+			if i > 0 && witness.Height != witnesses[i-1].Height+1 {
+				return fmt.Errorf("witnesses are not consecutive")
+			}
+			dbTx := tx.Table(m.table).Create(witness)
+			if dbTx.Error != nil {
+				return types.DbErrSqlOperation
+			}
+		}
+		return nil
+	})
+}
+
 func (m *defaultBlockWitnessModel) UpdateBlockWitnessStatus(witness *BlockWitness, status int64) error {
 	witness.Status = status
 	witness.UpdatedAt = time.Now()
@@ -133,3 +196,170 @@ func (m *defaultBlockWitnessModel) UpdateBlockWitnessStatus(witness *BlockWitnes
 	}
 	return nil
 }
+
+// AcquireBlockWitnessLease leases the lowest unproved block witness of the block sizes to the worker,
+// witnesses locked by other workers are skipped so that workers can prove different heights in parallel.
+func (m *defaultBlockWitnessModel) AcquireBlockWitnessLease(workerId string, blockSizes []int, leaseExpiredAt time.Time) (witness *BlockWitness, err error) {
+	err = m.DB.Transaction(func(tx *gorm.DB) error {
+		dbTx := tx.Table(m.table).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? and block_size IN ?", StatusPublished, blockSizes).Order("height asc").Limit(1).Find(&witness)
+		if dbTx.Error != nil {
+			return types.DbErrSqlOperation
+		} else if dbTx.RowsAffected == 0 {
+			return types.DbErrNotFound
+		}
+
+		now := time.Now()
+		witness.Status = StatusReceived
+		witness.WorkerId = workerId
+		witness.LeasedAt = now
+		witness.LeaseExpiredAt = leaseExpiredAt
+		dbTx = tx.Table(m.table).Where("id = ?", witness.ID).Updates(map[string]interface{}{
+			"status":           witness.Status,
+			"worker_id":        witness.WorkerId,
+			"leased_at":        witness.LeasedAt,
+			"lease_expired_at": witness.LeaseExpiredAt,
+			"updated_at":       now,
+		})
+		if dbTx.Error != nil {
+			return types.DbErrSqlOperation
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return witness, nil
+}
+
+// RenewBlockWitnessLease extends the lease held by the worker of the witness, DbErrNotFound is
+// returned when the lease has been reclaimed or taken by another worker.
+func (m *defaultBlockWitnessModel) RenewBlockWitnessLease(witness *BlockWitness, leaseExpiredAt time.Time) error {
+	dbTx := m.DB.Table(m.table).
+		Where("height = ? and status = ? and worker_id = ?", witness.Height, StatusReceived, witness.WorkerId).
+		Updates(map[string]interface{}{
+			"lease_expired_at": leaseExpiredAt,
+			"updated_at":       time.Now(),
+		})
+	if dbTx.Error != nil {
+		return types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return types.DbErrNotFound
+	}
+	witness.LeaseExpiredAt = leaseExpiredAt
+	return nil
+}
+
+// ReleaseBlockWitnessLease gives the witness back to the pool if the worker still holds the lease.
+func (m *defaultBlockWitnessModel) ReleaseBlockWitnessLease(witness *BlockWitness) error {
+	dbTx := m.DB.Table(m.table).
+		Where("height = ? and status = ? and worker_id = ?", witness.Height, StatusReceived, witness.WorkerId).
+		Updates(map[string]interface{}{
+			"status":     StatusPublished,
+			"worker_id":  "",
+			"updated_at": time.Now(),
+		})
+	if dbTx.Error != nil {
+		return types.DbErrSqlOperation
+	}
+	witness.Status = StatusPublished
+	witness.WorkerId = ""
+	return nil
+}
+
+// ReclaimExpiredBlockWitnessLeases gives all the witnesses whose leases are expired back to the pool.
+func (m *defaultBlockWitnessModel) ReclaimExpiredBlockWitnessLeases(now time.Time) (count int64, err error) {
+	dbTx := m.DB.Table(m.table).
+		Where("status = ? and lease_expired_at < ?", StatusReceived, now).
+		Updates(map[string]interface{}{
+			"status":     StatusPublished,
+			"worker_id":  "",
+			"updated_at": now,
+		})
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	}
+	return dbTx.RowsAffected, nil
+}
+
+// GetLeasedBlockWitnesses returns the in-flight witnesses without the witness data.
+func (m *defaultBlockWitnessModel) GetLeasedBlockWitnesses() (witnesses []*BlockWitness, err error) {
+	dbTx := m.DB.Table(m.table).Select("id, height, status, worker_id, leased_at, lease_expired_at").
+		Where("status = ?", StatusReceived).Order("height asc").Find(&witnesses)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return witnesses, nil
+}
+
+// RescheduleBlockWitnessInTransact gives the witness back to the pool regardless of its status,
+// it's used when the proof of the block turns out to be invalid.
+func (m *defaultBlockWitnessModel) RescheduleBlockWitnessInTransact(tx *gorm.DB, height int64) error {
+	dbTx := tx.Table(m.table).Where("height = ?", height).Updates(map[string]interface{}{
+		"status":     StatusPublished,
+		"worker_id":  "",
+		"updated_at": time.Now(),
+	})
+	if dbTx.Error != nil {
+		return dbTx.Error
+	} else if dbTx.RowsAffected == 0 {
+		return types.DbErrNotFound
+	}
+	return nil
+}
+
+func (m *defaultBlockWitnessModel) GetBlockWitnessesByEncoding(encoding int64, limit int) (witnesses []*BlockWitness, err error) {
+	dbTx := m.DB.Table(m.table).Where("encoding = ?", encoding).Order("height asc").Limit(limit).Find(&witnesses)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return witnesses, nil
+}
+
+// UpdateBlockWitnessData updates the encoding and the data of the witness.
+func (m *defaultBlockWitnessModel) UpdateBlockWitnessData(witness *BlockWitness) error {
+	dbTx := m.DB.Table(m.table).Where("id = ?", witness.ID).
+		Select("WitnessData", "Encoding", "WitnessBlob", "WitnessHash").Updates(witness)
+	if dbTx.Error != nil {
+		return types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return types.DbErrNotFound
+	}
+	return nil
+}
+
+// DeleteBlockWitnessesInTransact deletes the witnesses from the height permanently, it's used when the
+// blocks are reverted on L1, so that the witnesses are generated again and the blocks are proved again.
+// Their blobs are left in the witness storage on purpose: the blobs are addressed by content, so the
+// witnesses generated again for the same blocks find them there instead of uploading them again.
+func (m *defaultBlockWitnessModel) DeleteBlockWitnessesInTransact(tx *gorm.DB, fromHeight int64) error {
+	dbTx := tx.Table(m.table).Unscoped().Where("height >= ?", fromHeight).Delete(&BlockWitness{})
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	return nil
+}
+
+// GetOldestUnprovedBlockWitness returns the lowest witness which is not proved yet, without the witness data.
+func (m *defaultBlockWitnessModel) GetOldestUnprovedBlockWitness() (witness *BlockWitness, err error) {
+	dbTx := m.DB.Table(m.table).Select("id, created_at, height, status, worker_id, leased_at, lease_expired_at").
+		Where("status <> ?", StatusProved).Order("height asc").Limit(1).Find(&witness)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return witness, nil
+}
+
+func (m *defaultBlockWitnessModel) GetBlockWitnessesCountBetween(from, to time.Time) (count int64, err error) {
+	dbTx := m.DB.Table(m.table).Where("created_at BETWEEN ? AND ? AND deleted_at is NULL", from, to).Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	}
+	return count, nil
+}
@@ -19,6 +19,8 @@ package l1rolluptx
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -43,8 +45,11 @@ type (
 		GetLatestHandledTx(txType int64) (tx *L1RollupTx, err error)
 		GetLatestPendingTx(txType int64) (tx *L1RollupTx, err error)
 		GetL1RollupTxsByStatus(txStatus int) (txs []*L1RollupTx, err error)
+		GetL1RollupTxsCountBetween(txType int64, from, to time.Time) (count int64, err error)
 		DeleteL1RollupTx(tx *L1RollupTx) error
 		UpdateL1RollupTxsInTransact(tx *gorm.DB, txs []*L1RollupTx) error
+		ReplaceL1RollupTx(tx *L1RollupTx, txHash string, gasPrice string) error
+		RevertL1RollupTxsInTransact(tx *gorm.DB, committedHeight int64) error
 	}
 
 	defaultL1RollupTxModel struct {
@@ -62,9 +67,27 @@ type (
 		TxType uint8
 		// layer-2 block height
 		L2BlockHeight int64
+		// nonce and gas price in wei of the latest sent tx
+		L1Nonce  uint64
+		GasPrice string
+		// comma separated hashes of the txs which are replaced by the latest sent tx
+		ReplacedTxHashes string
 	}
 )
 
+// TxHashes returns the hashes of all the sent txs with the same nonce, the latest one first.
+func (tx *L1RollupTx) TxHashes() []string {
+	hashes := []string{tx.L1TxHash}
+	if tx.ReplacedTxHashes == "" {
+		return hashes
+	}
+	replaced := strings.Split(tx.ReplacedTxHashes, ",")
+	for i := len(replaced) - 1; i >= 0; i-- {
+		hashes = append(hashes, replaced[i])
+	}
+	return hashes
+}
+
 func (*L1RollupTx) TableName() string {
 	return TableName
 }
@@ -77,7 +100,12 @@ func NewL1RollupTxModel(db *gorm.DB) L1RollupTxModel {
 }
 
 func (m *defaultL1RollupTxModel) CreateL1RollupTxTable() error {
-	return m.DB.AutoMigrate(L1RollupTx{})
+	err := m.DB.AutoMigrate(L1RollupTx{})
+	if err != nil {
+		return err
+	}
+	// The rollup txs sent in a time window are counted by tx type and created_at.
+	return m.DB.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_created_at ON %s (created_at)", m.table, m.table)).Error
 }
 
 func (m *defaultL1RollupTxModel) DropL1RollupTxTable() error {
@@ -155,3 +183,71 @@ func (m *defaultL1RollupTxModel) UpdateL1RollupTxsInTransact(tx *gorm.DB, txs []
 	}
 	return nil
 }
+
+// ReplaceL1RollupTx records the tx which replaces the pending tx with the same nonce, the hash of the
+// replaced tx is kept so that it is still followed in case it lands.
+func (m *defaultL1RollupTxModel) ReplaceL1RollupTx(rollupTx *L1RollupTx, txHash string, gasPrice string) error {
+	replacedTxHashes := rollupTx.L1TxHash
+	if rollupTx.ReplacedTxHashes != "" {
+		replacedTxHashes = rollupTx.ReplacedTxHashes + "," + rollupTx.L1TxHash
+	}
+	updatedAt := time.Now()
+	dbTx := m.DB.Table(m.table).Where("id = ? AND l1_tx_hash = ?", rollupTx.ID, rollupTx.L1TxHash).
+		Updates(map[string]interface{}{
+			"updated_at":         updatedAt,
+			"l1_tx_hash":         txHash,
+			"gas_price":          gasPrice,
+			"replaced_tx_hashes": replacedTxHashes,
+		})
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	if dbTx.RowsAffected == 0 {
+		return fmt.Errorf("invalid rollup tx: %d", rollupTx.ID)
+	}
+	rollupTx.UpdatedAt = updatedAt
+	rollupTx.L1TxHash = txHash
+	rollupTx.GasPrice = gasPrice
+	rollupTx.ReplacedTxHashes = replacedTxHashes
+	return nil
+}
+
+// RevertL1RollupTxsInTransact deletes the rollup txs of the blocks above the committed height on L1.
+// The commit tx whose blocks are partially reverted is kept for the blocks which are still committed,
+// so that the blocks are committed again from the last stored block.
+func (m *defaultL1RollupTxModel) RevertL1RollupTxsInTransact(tx *gorm.DB, committedHeight int64) error {
+	var lastCommitTx L1RollupTx
+	dbTx := tx.Table(m.table).Where("tx_type = ? AND tx_status = ? AND l2_block_height <= ?",
+		TxTypeCommit, StatusHandled, committedHeight).Order("l2_block_height desc").Limit(1).Find(&lastCommitTx)
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	if dbTx.RowsAffected == 0 || lastCommitTx.L2BlockHeight < committedHeight {
+		var revertedCommitTx L1RollupTx
+		dbTx = tx.Table(m.table).Where("tx_type = ? AND tx_status = ? AND l2_block_height > ?",
+			TxTypeCommit, StatusHandled, committedHeight).Order("l2_block_height").Limit(1).Find(&revertedCommitTx)
+		if dbTx.Error != nil {
+			return dbTx.Error
+		}
+		if dbTx.RowsAffected > 0 {
+			dbTx = tx.Table(m.table).Where("id = ?", revertedCommitTx.ID).Update("l2_block_height", committedHeight)
+			if dbTx.Error != nil {
+				return dbTx.Error
+			}
+		}
+	}
+	dbTx = tx.Table(m.table).Where("l2_block_height > ?", committedHeight).Delete(&L1RollupTx{})
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	return nil
+}
+
+func (m *defaultL1RollupTxModel) GetL1RollupTxsCountBetween(txType int64, from, to time.Time) (count int64, err error) {
+	dbTx := m.DB.Table(m.table).Where("tx_type = ? AND created_at BETWEEN ? AND ? AND deleted_at is NULL",
+		txType, from, to).Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	}
+	return count, nil
+}
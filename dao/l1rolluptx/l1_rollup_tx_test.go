@@ -0,0 +1,137 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package l1rolluptx
+
+import (
+	"fmt"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var dsn = "host=localhost user=postgres password=ZkBNB@123 dbname=zkbnb port=5435 sslmode=disable"
+
+type rollupTx struct {
+	TxType        uint8
+	TxStatus      int
+	L2BlockHeight int64
+}
+
+func TestRevertL1RollupTxs(t *testing.T) {
+	testDBSetup()
+	defer testDBShutdown()
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+	model := NewL1RollupTxModel(db)
+
+	sentTxs := []rollupTx{
+		// blocks 1 to 5 and 6 to 10 are committed, blocks 1 to 5 are verified
+		{TxTypeCommit, StatusHandled, 5},
+		{TxTypeCommit, StatusHandled, 10},
+		{TxTypeVerifyAndExecute, StatusHandled, 5},
+		// blocks 11 to 15 are being committed, block 6 is being verified
+		{TxTypeCommit, StatusPending, 15},
+		{TxTypeVerifyAndExecute, StatusPending, 6},
+	}
+	testCases := []struct {
+		name            string
+		committedHeight int64
+		expected        []rollupTx
+	}{
+		{
+			name:            "full revert of a commit tx",
+			committedHeight: 5,
+			expected: []rollupTx{
+				{TxTypeCommit, StatusHandled, 5},
+				{TxTypeVerifyAndExecute, StatusHandled, 5},
+			},
+		},
+		{
+			name:            "partial revert of a commit tx",
+			committedHeight: 8,
+			expected: []rollupTx{
+				{TxTypeCommit, StatusHandled, 5},
+				{TxTypeCommit, StatusHandled, 8},
+				{TxTypeVerifyAndExecute, StatusHandled, 5},
+				{TxTypeVerifyAndExecute, StatusPending, 6},
+			},
+		},
+		{
+			name:            "revert of the pending commit tx",
+			committedHeight: 10,
+			expected: []rollupTx{
+				{TxTypeCommit, StatusHandled, 5},
+				{TxTypeCommit, StatusHandled, 10},
+				{TxTypeVerifyAndExecute, StatusHandled, 5},
+				{TxTypeVerifyAndExecute, StatusPending, 6},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, model.DropL1RollupTxTable())
+			assert.NoError(t, model.CreateL1RollupTxTable())
+			for i, sentTx := range sentTxs {
+				assert.NoError(t, model.CreateL1RollupTx(&L1RollupTx{
+					L1TxHash:      fmt.Sprintf("0x%x", i),
+					TxStatus:      sentTx.TxStatus,
+					TxType:        sentTx.TxType,
+					L2BlockHeight: sentTx.L2BlockHeight,
+				}))
+			}
+
+			assert.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+				return model.RevertL1RollupTxsInTransact(tx, tc.committedHeight)
+			}))
+
+			var txs []*L1RollupTx
+			assert.NoError(t, db.Table(TableName).Order("tx_type, l2_block_height").Find(&txs).Error)
+			actual := make([]rollupTx, 0, len(txs))
+			for _, tx := range txs {
+				actual = append(actual, rollupTx{tx.TxType, tx.TxStatus, tx.L2BlockHeight})
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func testDBSetup() {
+	testDBShutdown()
+	time.Sleep(5 * time.Second)
+	cmd := exec.Command("docker", "run", "--name", "postgres-ut-l1rolluptx", "-p", "5435:5432",
+		"-e", "POSTGRES_PASSWORD=ZkBNB@123", "-e", "POSTGRES_USER=postgres", "-e", "POSTGRES_DB=zkbnb",
+		"-e", "PGDATA=/var/lib/postgresql/pgdata", "-d", "ghcr.io/bnb-chain/zkbnb/zkbnb-ut-postgres:0.0.2")
+	if err := cmd.Run(); err != nil {
+		panic(err)
+	}
+	time.Sleep(5 * time.Second)
+}
+
+func testDBShutdown() {
+	cmd := exec.Command("docker", "kill", "postgres-ut-l1rolluptx")
+	//nolint:errcheck
+	cmd.Run()
+	time.Sleep(time.Second)
+	cmd = exec.Command("docker", "rm", "postgres-ut-l1rolluptx")
+	//nolint:errcheck
+	cmd.Run()
+}
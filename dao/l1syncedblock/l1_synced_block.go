@@ -18,6 +18,9 @@
 package l1syncedblock
 
 import (
+	"fmt"
+	"time"
+
 	"gorm.io/gorm"
 
 	"github.com/bnb-chain/zkbnb/types"
@@ -35,7 +38,14 @@ type (
 		CreateL1SyncedBlockTable() error
 		DropL1SyncedBlockTable() error
 		GetLatestL1BlockByType(blockType int) (blockInfo *L1SyncedBlock, err error)
+		GetLatestL1BlockByTypeBefore(blockType int, before time.Time) (blockInfo *L1SyncedBlock, err error)
 		CreateL1SyncedBlockInTransact(tx *gorm.DB, block *L1SyncedBlock) error
+		GetLatestL1BlocksByType(blockType int, limit int) (blocks []*L1SyncedBlock, err error)
+		DeleteL1SyncedBlocksInTransact(tx *gorm.DB, blockType int, forkHeight int64) error
+		GetL1SyncedBlocksCovering(blockType int, fromHeight int64, toHeight int64) (blocks []*L1SyncedBlock, err error)
+		UpdateL1SyncedBlockInTransact(tx *gorm.DB, block *L1SyncedBlock) error
+		GetL1SyncedBlocksWithEvents(blockType int) (blocks []*L1SyncedBlock, err error)
+		GetL1SyncedBlocksAbove(blockType int, height int64) (blocks []*L1SyncedBlock, err error)
 	}
 
 	defaultL1EventModel struct {
@@ -47,6 +57,8 @@ type (
 		gorm.Model
 		// l1 block height
 		L1BlockHeight int64
+		// hash of the l1 block at L1BlockHeight, it's used to detect the l1 reorgs
+		L1BlockHash string
 		// block info, array of hashes
 		BlockInfo string
 		Type      int
@@ -65,7 +77,12 @@ func NewL1SyncedBlockModel(db *gorm.DB) L1SyncedBlockModel {
 }
 
 func (m *defaultL1EventModel) CreateL1SyncedBlockTable() error {
-	return m.DB.AutoMigrate(L1SyncedBlock{})
+	err := m.DB.AutoMigrate(L1SyncedBlock{})
+	if err != nil {
+		return err
+	}
+	// The latest synced block before a time is looked up by created_at.
+	return m.DB.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_created_at ON %s (created_at)", m.table, m.table)).Error
 }
 
 func (m *defaultL1EventModel) DropL1SyncedBlockTable() error {
@@ -93,3 +110,93 @@ func (m *defaultL1EventModel) CreateL1SyncedBlockInTransact(tx *gorm.DB, block *
 	}
 	return nil
 }
+
+func (m *defaultL1EventModel) GetLatestL1BlocksByType(blockType int, limit int) (blocks []*L1SyncedBlock, err error) {
+	dbTx := m.DB.Table(m.table).Where("type = ?", blockType).Order("l1_block_height desc").Limit(limit).Find(&blocks)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	}
+	if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return blocks, nil
+}
+
+// DeleteL1SyncedBlocksInTransact deletes the synced blocks above the fork height, so that they are synced again.
+func (m *defaultL1EventModel) DeleteL1SyncedBlocksInTransact(tx *gorm.DB, blockType int, forkHeight int64) error {
+	dbTx := tx.Table(m.table).Where("type = ? AND l1_block_height > ?", blockType, forkHeight).Delete(&L1SyncedBlock{})
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	return nil
+}
+
+// GetL1SyncedBlocksCovering returns the synced blocks in order which cover the l1 blocks from fromHeight to toHeight,
+// each synced block covers the l1 blocks after the previous one up to its height.
+func (m *defaultL1EventModel) GetL1SyncedBlocksCovering(blockType int, fromHeight int64, toHeight int64) (blocks []*L1SyncedBlock, err error) {
+	var last L1SyncedBlock
+	dbTx := m.DB.Table(m.table).Where("type = ? AND l1_block_height >= ?", blockType, toHeight).
+		Order("l1_block_height").Limit(1).Find(&last)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	}
+	if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	dbTx = m.DB.Table(m.table).Where("type = ? AND l1_block_height >= ? AND l1_block_height <= ?",
+		blockType, fromHeight, last.L1BlockHeight).Order("l1_block_height").Find(&blocks)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	}
+	return blocks, nil
+}
+
+func (m *defaultL1EventModel) UpdateL1SyncedBlockInTransact(tx *gorm.DB, block *L1SyncedBlock) error {
+	dbTx := tx.Table(m.table).Where("id = ?", block.ID).Select("*").Updates(block)
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	if dbTx.RowsAffected == 0 {
+		return types.DbErrFailToL1SyncedBlock
+	}
+	return nil
+}
+
+// GetL1SyncedBlocksWithEvents returns the synced blocks which record any event, the latest one first.
+func (m *defaultL1EventModel) GetL1SyncedBlocksWithEvents(blockType int) (blocks []*L1SyncedBlock, err error) {
+	dbTx := m.DB.Table(m.table).Where("type = ? AND block_info NOT IN ?", blockType, []string{"", "null", "[]"}).
+		Order("l1_block_height desc").Find(&blocks)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	}
+	if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return blocks, nil
+}
+
+// GetLatestL1BlockByTypeBefore returns the latest block which was synced before the time.
+func (m *defaultL1EventModel) GetLatestL1BlockByTypeBefore(blockType int, before time.Time) (blockInfo *L1SyncedBlock, err error) {
+	dbTx := m.DB.Table(m.table).Where("type = ? AND created_at < ?", blockType, before).
+		Order("l1_block_height desc").Limit(1).Find(&blockInfo)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	}
+	if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return blockInfo, nil
+}
+
+// GetL1SyncedBlocksAbove returns the synced blocks above the height in order.
+func (m *defaultL1EventModel) GetL1SyncedBlocksAbove(blockType int, height int64) (blocks []*L1SyncedBlock, err error) {
+	dbTx := m.DB.Table(m.table).Where("type = ? AND l1_block_height > ?", blockType, height).
+		Order("l1_block_height").Find(&blocks)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	}
+	if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return blocks, nil
+}
@@ -40,7 +40,9 @@ type (
 		DropMempoolTxTable() error
 		GetMempoolTxs(limit int64, offset int64) (mempoolTxs []*MempoolTx, err error)
 		GetMempoolTxsTotalCount() (count int64, err error)
+		GetOldestPendingMempoolTx() (mempoolTx *MempoolTx, err error)
 		GetMempoolTxByTxHash(hash string) (mempoolTxs *MempoolTx, err error)
+		GetMempoolTxsByTxHashes(hashes []string) (mempoolTxs []*MempoolTx, err error)
 		GetMempoolTxsByStatus(status int) (mempoolTxs []*MempoolTx, err error)
 		GetMempoolTxsByBlockHeight(l2BlockHeight int64) (rowsAffected int64, mempoolTxs []*MempoolTx, err error)
 		CreateMempoolTxs(mempoolTxs []*MempoolTx) error
@@ -50,6 +52,7 @@ type (
 		CreateMempoolTxsInTransact(tx *gorm.DB, mempoolTxs []*MempoolTx) error
 		UpdateMempoolTxsInTransact(tx *gorm.DB, mempoolTxs []*MempoolTx) error
 		DeleteMempoolTxsInTransact(tx *gorm.DB, mempoolTxs []*MempoolTx) error
+		DeletePendingMempoolTxsByHashesInTransact(tx *gorm.DB, txHashes []string) error
 	}
 
 	defaultMempoolModel struct {
@@ -146,6 +149,18 @@ func (m *defaultMempoolModel) GetMempoolTxByTxHash(hash string) (mempoolTx *Memp
 	return mempoolTx, nil
 }
 
+// GetMempoolTxsByTxHashes returns the txs in any status, the txs which are not found are left out.
+func (m *defaultMempoolModel) GetMempoolTxsByTxHashes(hashes []string) (mempoolTxs []*MempoolTx, err error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+	dbTx := m.DB.Table(m.table).Where("tx_hash in ?", hashes).Find(&mempoolTxs)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	}
+	return mempoolTxs, nil
+}
+
 func (m *defaultMempoolModel) CreateMempoolTxs(mempoolTxs []*MempoolTx) error {
 	return m.DB.Transaction(func(tx *gorm.DB) error { // transact
 		dbTx := tx.Table(m.table).Create(mempoolTxs)
@@ -258,3 +273,31 @@ func (m *defaultMempoolModel) DeleteMempoolTxsInTransact(tx *gorm.DB, mempoolTxs
 	}
 	return nil
 }
+
+// DeletePendingMempoolTxsByHashesInTransact deletes the txs which are not executed yet, it fails if any of them is
+// executed or missing. The txs are deleted permanently as the same tx hashes may be added again.
+func (m *defaultMempoolModel) DeletePendingMempoolTxsByHashesInTransact(tx *gorm.DB, txHashes []string) error {
+	if len(txHashes) == 0 {
+		return nil
+	}
+	dbTx := tx.Table(m.table).Unscoped().Where("status = ? AND tx_hash in ?", PendingTxStatus, txHashes).
+		Delete(&MempoolTx{})
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	if dbTx.RowsAffected != int64(len(txHashes)) {
+		return types.DbErrFailToDeleteMempoolTx
+	}
+	return nil
+}
+
+func (m *defaultMempoolModel) GetOldestPendingMempoolTx() (mempoolTx *MempoolTx, err error) {
+	dbTx := m.DB.Table(m.table).Select("id, tx_hash, tx_type, created_at").Where("status = ?", PendingTxStatus).
+		Order("created_at, id").Limit(1).Find(&mempoolTx)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return mempoolTx, nil
+}
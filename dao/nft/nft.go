@@ -35,6 +35,8 @@ type (
 		GetLatestNftIndex() (nftIndex int64, err error)
 		GetNftsByAccountIndex(accountIndex, limit, offset int64) (nfts []*L2Nft, err error)
 		GetNftsCountByAccountIndex(accountIndex int64) (int64, error)
+		GetNftsByCollectionId(creatorAccountIndex, collectionId, limit, offset int64) (nfts []*L2Nft, err error)
+		GetNftsCountByCollectionId(creatorAccountIndex, collectionId int64) (int64, error)
 		CreateNftsInTransact(tx *gorm.DB, nfts []*L2Nft) error
 		UpdateNftsInTransact(tx *gorm.DB, nfts []*L2Nft) error
 	}
@@ -46,13 +48,13 @@ type (
 	L2Nft struct {
 		gorm.Model
 		NftIndex            int64 `gorm:"uniqueIndex"`
-		CreatorAccountIndex int64
-		OwnerAccountIndex   int64
+		CreatorAccountIndex int64 `gorm:"index:idx_nft_collection"`
+		OwnerAccountIndex   int64 `gorm:"index"`
 		NftContentHash      string
 		NftL1Address        string
 		NftL1TokenId        string
 		CreatorTreasuryRate int64
-		CollectionId        int64
+		CollectionId        int64 `gorm:"index:idx_nft_collection"`
 	}
 )
 
@@ -118,6 +120,26 @@ func (m *defaultL2NftModel) GetNftsCountByAccountIndex(accountIndex int64) (int6
 	return count, nil
 }
 
+func (m *defaultL2NftModel) GetNftsByCollectionId(creatorAccountIndex, collectionId, limit, offset int64) (nftList []*L2Nft, err error) {
+	dbTx := m.DB.Table(m.table).Where("creator_account_index = ? and collection_id = ? and deleted_at is NULL", creatorAccountIndex, collectionId).
+		Limit(int(limit)).Offset(int(offset)).Order("nft_index desc").Find(&nftList)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return nftList, nil
+}
+
+func (m *defaultL2NftModel) GetNftsCountByCollectionId(creatorAccountIndex, collectionId int64) (int64, error) {
+	var count int64
+	dbTx := m.DB.Table(m.table).Where("creator_account_index = ? and collection_id = ? and deleted_at is NULL", creatorAccountIndex, collectionId).Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	}
+	return count, nil
+}
+
 func (m *defaultL2NftModel) CreateNftsInTransact(tx *gorm.DB, nfts []*L2Nft) error {
 	dbTx := tx.Table(m.table).CreateInBatches(nfts, len(nfts))
 	if dbTx.Error != nil {
@@ -35,6 +35,8 @@ type (
 		GetLatestNftsByBlockHeight(height int64, limit int, offset int) (
 			rowsAffected int64, nftAssets []*L2NftHistory, err error,
 		)
+		GetNftHistoriesCount(nftIndex int64) (count int64, err error)
+		GetNftHistories(nftIndex int64, limit int64, offset int64) (histories []*L2NftHistory, err error)
 		CreateNftHistoriesInTransact(tx *gorm.DB, histories []*L2NftHistory) error
 	}
 	defaultL2NftHistoryModel struct {
@@ -44,7 +46,7 @@ type (
 
 	L2NftHistory struct {
 		gorm.Model
-		NftIndex            int64
+		NftIndex            int64 `gorm:"index:idx_nft_history_index_height"`
 		CreatorAccountIndex int64
 		OwnerAccountIndex   int64
 		NftContentHash      string
@@ -53,7 +55,7 @@ type (
 		CreatorTreasuryRate int64
 		CollectionId        int64
 		Status              int
-		L2BlockHeight       int64
+		L2BlockHeight       int64 `gorm:"index:idx_nft_history_index_height"`
 	}
 )
 
@@ -109,6 +111,27 @@ func (m *defaultL2NftHistoryModel) GetLatestNftsByBlockHeight(height int64, limi
 	return dbTx.RowsAffected, accountNftAssets, nil
 }
 
+func (m *defaultL2NftHistoryModel) GetNftHistoriesCount(nftIndex int64) (count int64, err error) {
+	dbTx := m.DB.Table(m.table).Where("nft_index = ? and deleted_at is NULL", nftIndex).Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return 0, nil
+	}
+	return count, nil
+}
+
+func (m *defaultL2NftHistoryModel) GetNftHistories(nftIndex int64, limit int64, offset int64) (histories []*L2NftHistory, err error) {
+	dbTx := m.DB.Table(m.table).Where("nft_index = ? and deleted_at is NULL", nftIndex).
+		Limit(int(limit)).Offset(int(offset)).Order("l2_block_height desc").Find(&histories)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return histories, nil
+}
+
 func (m *defaultL2NftHistoryModel) CreateNftHistoriesInTransact(tx *gorm.DB, histories []*L2NftHistory) error {
 	dbTx := tx.Table(m.table).CreateInBatches(histories, len(histories))
 	if dbTx.Error != nil {
@@ -0,0 +1,201 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package offer
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	OfferTableName = `offer`
+)
+
+const (
+	StatusPending = iota
+	StatusFinalized
+	StatusCanceled
+)
+
+// NilOfferFilter means that the field of OfferQuery will not be used for filtering.
+const NilOfferFilter = int64(-1)
+
+type (
+	OfferModel interface {
+		CreateOfferTable() error
+		DropOfferTable() error
+		CreateOffer(offer *Offer) error
+		GetOffer(accountIndex, offerId int64) (offer *Offer, err error)
+		GetOffersCount(query *OfferQuery) (count int64, err error)
+		GetOffers(query *OfferQuery, limit, offset int64) (offers []*Offer, err error)
+		UpdateOffersStatusInTransact(tx *gorm.DB, offers []*Offer) error
+	}
+
+	defaultOfferModel struct {
+		table string
+		DB    *gorm.DB
+	}
+
+	Offer struct {
+		gorm.Model
+		OfferType    int64
+		OfferId      int64 `gorm:"uniqueIndex:idx_account_offer"`
+		AccountIndex int64 `gorm:"uniqueIndex:idx_account_offer"`
+		NftIndex     int64 `gorm:"index"`
+		CollectionId int64 `gorm:"index"`
+		AssetId      int64
+		AssetAmount  string
+		ListedAt     int64
+		ExpiredAt    int64
+		TreasuryRate int64
+		// The signed offer in json, which is used for composing the AtomicMatch tx.
+		OfferInfo string
+		Status    int64 `gorm:"index"`
+		// Hash of the AtomicMatch or CancelOffer tx which finalized or canceled the offer.
+		TxHash string
+	}
+
+	// OfferQuery filters the offers, fields set to NilOfferFilter are ignored.
+	OfferQuery struct {
+		AccountIndex int64
+		NftIndex     int64
+		CollectionId int64
+		AssetId      int64
+		OfferType    int64
+		Status       int64
+		// Only the offers expired before (exclusive) or after (inclusive) the timestamp are returned, 0 is ignored.
+		ExpiredBefore int64
+		ExpiredAfter  int64
+	}
+)
+
+func NewOfferModel(db *gorm.DB) OfferModel {
+	return &defaultOfferModel{
+		table: OfferTableName,
+		DB:    db,
+	}
+}
+
+func NewOfferQuery() *OfferQuery {
+	return &OfferQuery{
+		AccountIndex: NilOfferFilter,
+		NftIndex:     NilOfferFilter,
+		CollectionId: NilOfferFilter,
+		AssetId:      NilOfferFilter,
+		OfferType:    NilOfferFilter,
+		Status:       NilOfferFilter,
+	}
+}
+
+func (*Offer) TableName() string {
+	return OfferTableName
+}
+
+func (m *defaultOfferModel) CreateOfferTable() error {
+	return m.DB.AutoMigrate(Offer{})
+}
+
+func (m *defaultOfferModel) DropOfferTable() error {
+	return m.DB.Migrator().DropTable(m.table)
+}
+
+func (m *defaultOfferModel) CreateOffer(offer *Offer) error {
+	dbTx := m.DB.Table(m.table).Create(offer)
+	if dbTx.Error != nil {
+		return types.DbErrSqlOperation
+	}
+	if dbTx.RowsAffected == 0 {
+		return types.DbErrFailToCreateOffer
+	}
+	return nil
+}
+
+func (m *defaultOfferModel) GetOffer(accountIndex, offerId int64) (offer *Offer, err error) {
+	dbTx := m.DB.Table(m.table).Where("account_index = ? and offer_id = ?", accountIndex, offerId).Find(&offer)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return offer, nil
+}
+
+func (m *defaultOfferModel) GetOffersCount(query *OfferQuery) (count int64, err error) {
+	dbTx := m.filter(query).Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return 0, nil
+	}
+	return count, nil
+}
+
+func (m *defaultOfferModel) GetOffers(query *OfferQuery, limit, offset int64) (offers []*Offer, err error) {
+	dbTx := m.filter(query).Limit(int(limit)).Offset(int(offset)).Order("listed_at desc, id desc").Find(&offers)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return offers, nil
+}
+
+func (m *defaultOfferModel) UpdateOffersStatusInTransact(tx *gorm.DB, offers []*Offer) error {
+	for _, pendingOffer := range offers {
+		// The offers are not required to be in the offer book, so no rows affected is fine here.
+		dbTx := tx.Table(m.table).Where("account_index = ? and offer_id = ?", pendingOffer.AccountIndex, pendingOffer.OfferId).
+			Updates(map[string]interface{}{
+				"status":  pendingOffer.Status,
+				"tx_hash": pendingOffer.TxHash,
+			})
+		if dbTx.Error != nil {
+			return dbTx.Error
+		}
+	}
+	return nil
+}
+
+func (m *defaultOfferModel) filter(query *OfferQuery) *gorm.DB {
+	dbTx := m.DB.Table(m.table).Where("deleted_at is NULL")
+	if query.AccountIndex != NilOfferFilter {
+		dbTx = dbTx.Where("account_index = ?", query.AccountIndex)
+	}
+	if query.NftIndex != NilOfferFilter {
+		dbTx = dbTx.Where("nft_index = ?", query.NftIndex)
+	}
+	if query.CollectionId != NilOfferFilter {
+		dbTx = dbTx.Where("collection_id = ?", query.CollectionId)
+	}
+	if query.AssetId != NilOfferFilter {
+		dbTx = dbTx.Where("asset_id = ?", query.AssetId)
+	}
+	if query.OfferType != NilOfferFilter {
+		dbTx = dbTx.Where("offer_type = ?", query.OfferType)
+	}
+	if query.Status != NilOfferFilter {
+		dbTx = dbTx.Where("status = ?", query.Status)
+	}
+	if query.ExpiredBefore != 0 {
+		dbTx = dbTx.Where("expired_at < ?", query.ExpiredBefore)
+	}
+	if query.ExpiredAfter != 0 {
+		dbTx = dbTx.Where("expired_at >= ?", query.ExpiredAfter)
+	}
+	return dbTx
+}
@@ -38,6 +38,12 @@ type (
 		GetLatestHandledRequestId() (requestId int64, err error)
 		UpdateHandledPriorityRequestsInTransact(tx *gorm.DB, requests []*PriorityRequest) (err error)
 		CreatePriorityRequestsInTransact(tx *gorm.DB, requests []*PriorityRequest) (err error)
+		GetPriorityRequestsAboveL1Height(l1Height int64) (requests []*PriorityRequest, err error)
+		DeletePriorityRequestsInTransact(tx *gorm.DB, requests []*PriorityRequest) (err error)
+		GetLatestRequestId() (requestId int64, err error)
+		GetPriorityRequestsFromRequestId(requestId int64) (requests []*PriorityRequest, err error)
+		GetPriorityRequestsBetweenL1Heights(fromHeight int64, toHeight int64) (requests []*PriorityRequest, err error)
+		GetPriorityRequestByRequestId(requestId int64) (request *PriorityRequest, err error)
 	}
 
 	defaultPriorityRequestModel struct {
@@ -143,3 +149,75 @@ func (m *defaultPriorityRequestModel) CreatePriorityRequestsInTransact(tx *gorm.
 	}
 	return nil
 }
+
+func (m *defaultPriorityRequestModel) GetPriorityRequestsAboveL1Height(l1Height int64) (requests []*PriorityRequest, err error) {
+	dbTx := m.DB.Table(m.table).Where("l1_block_height > ?", l1Height).Order("request_id").Find(&requests)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return requests, nil
+}
+
+func (m *defaultPriorityRequestModel) DeletePriorityRequestsInTransact(tx *gorm.DB, requests []*PriorityRequest) (err error) {
+	if len(requests) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(requests))
+	for _, request := range requests {
+		ids = append(ids, request.ID)
+	}
+	dbTx := tx.Table(m.table).Where("id in ?", ids).Delete(&PriorityRequest{})
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	if dbTx.RowsAffected != int64(len(ids)) {
+		return types.DbErrFailToDeletePriorityRequest
+	}
+	return nil
+}
+
+// GetLatestRequestId returns the id of the latest synced request in any status, or -1 if there is none.
+func (m *defaultPriorityRequestModel) GetLatestRequestId() (requestId int64, err error) {
+	var request *PriorityRequest
+	dbTx := m.DB.Table(m.table).Order("request_id desc").Limit(1).Find(&request)
+	if dbTx.Error != nil {
+		return -1, types.DbErrSqlOperation
+	}
+	if dbTx.RowsAffected == 0 {
+		return -1, nil
+	}
+	return request.RequestId, nil
+}
+
+func (m *defaultPriorityRequestModel) GetPriorityRequestsFromRequestId(requestId int64) (requests []*PriorityRequest, err error) {
+	dbTx := m.DB.Table(m.table).Where("request_id >= ?", requestId).Order("request_id").Find(&requests)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return requests, nil
+}
+
+func (m *defaultPriorityRequestModel) GetPriorityRequestsBetweenL1Heights(fromHeight int64, toHeight int64) (requests []*PriorityRequest, err error) {
+	dbTx := m.DB.Table(m.table).Where("l1_block_height >= ? AND l1_block_height <= ?", fromHeight, toHeight).
+		Order("request_id").Find(&requests)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return requests, nil
+}
+
+func (m *defaultPriorityRequestModel) GetPriorityRequestByRequestId(requestId int64) (request *PriorityRequest, err error) {
+	dbTx := m.DB.Table(m.table).Where("request_id = ?", requestId).Limit(1).Find(&request)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return request, nil
+}
@@ -18,6 +18,8 @@ package proof
 
 import (
 	"fmt"
+	"time"
+
 	"gorm.io/gorm"
 
 	"github.com/bnb-chain/zkbnb/types"
@@ -25,12 +27,20 @@ import (
 
 const (
 	TableName = "proof"
+
+	// numberIndex keeps the block number unique among the proofs which are not quarantined.
+	numberIndex = "idx_proof_number"
+	// legacyNumberIndex is the unique index of the block number before the proofs could be quarantined,
+	// it blocks proving the quarantined blocks again.
+	legacyNumberIndex = "idx_number"
 )
 
 const (
 	NotSent = iota
 	NotConfirmed
 	Confirmed
+	// Quarantined proofs failed the local verification, they are kept for investigation only.
+	Quarantined
 )
 
 type (
@@ -40,8 +50,13 @@ type (
 		CreateProof(row *Proof) error
 		GetProofsBetween(start int64, end int64) (proofs []*Proof, err error)
 		GetLatestConfirmedProof() (p *Proof, err error)
+		GetLatestProof() (p *Proof, err error)
+		GetOldestNotSentProof() (p *Proof, err error)
+		GetProofsCountBetween(from, to time.Time) (count int64, err error)
 		GetProofByBlockHeight(height int64) (p *Proof, err error)
 		UpdateProofsInTransact(tx *gorm.DB, m map[int64]int) error
+		QuarantineProofInTransact(tx *gorm.DB, p *Proof) error
+		DeleteProofsInTransact(tx *gorm.DB, fromHeight int64) error
 	}
 
 	defaultProofModel struct {
@@ -52,7 +67,7 @@ type (
 	Proof struct {
 		gorm.Model
 		ProofInfo   string
-		BlockNumber int64 `gorm:"index:idx_number,unique"`
+		BlockNumber int64
 		Status      int64
 	}
 )
@@ -69,7 +84,33 @@ func NewProofModel(db *gorm.DB) ProofModel {
 }
 
 func (m *defaultProofModel) CreateProofTable() error {
-	return m.DB.AutoMigrate(Proof{})
+	err := m.DB.AutoMigrate(Proof{})
+	if err != nil {
+		return err
+	}
+	// The proofs created in a time window are counted by the pipeline status.
+	err = m.DB.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_created_at ON %s (created_at)", m.table, m.table)).Error
+	if err != nil {
+		return err
+	}
+	return m.migrateNumberIndex()
+}
+
+// migrateNumberIndex replaces the legacy unique index of the block number with the one which leaves
+// out the quarantined proofs, it's safe to run on the existing tables.
+func (m *defaultProofModel) migrateNumberIndex() error {
+	migrator := m.DB.Migrator()
+	if migrator.HasIndex(&Proof{}, legacyNumberIndex) {
+		err := migrator.DropIndex(&Proof{}, legacyNumberIndex)
+		if err != nil {
+			return err
+		}
+	}
+	if migrator.HasIndex(&Proof{}, numberIndex) {
+		return nil
+	}
+	return m.DB.Exec(fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (block_number) WHERE status <> %d",
+		numberIndex, m.table, Quarantined)).Error
 }
 
 func (m *defaultProofModel) DropProofTable() error {
@@ -106,7 +147,7 @@ func (m *defaultProofModel) GetProofsBetween(start int64, end int64) (proofs []*
 
 func (m *defaultProofModel) GetLatestConfirmedProof() (p *Proof, err error) {
 	var row *Proof
-	dbTx := m.DB.Table(m.table).Where("status >= ?", NotConfirmed).Order("block_number desc").Limit(1).Find(&row)
+	dbTx := m.DB.Table(m.table).Where("status in ?", []int64{NotConfirmed, Confirmed}).Order("block_number desc").Limit(1).Find(&row)
 	if dbTx.Error != nil {
 		return nil, types.DbErrSqlOperation
 	} else if dbTx.RowsAffected == 0 {
@@ -118,7 +159,7 @@ func (m *defaultProofModel) GetLatestConfirmedProof() (p *Proof, err error) {
 
 func (m *defaultProofModel) GetProofByBlockHeight(num int64) (p *Proof, err error) {
 	var row *Proof
-	dbTx := m.DB.Table(m.table).Where("block_number = ?", num).Find(&row)
+	dbTx := m.DB.Table(m.table).Where("block_number = ? AND status <> ?", num, Quarantined).Find(&row)
 	if dbTx.Error != nil {
 		return nil, types.DbErrSqlOperation
 	} else if dbTx.RowsAffected == 0 {
@@ -131,7 +172,7 @@ func (m *defaultProofModel) GetProofByBlockHeight(num int64) (p *Proof, err erro
 func (m *defaultProofModel) UpdateProofsInTransact(tx *gorm.DB, proofs map[int64]int) error {
 	for blockHeight, newStatus := range proofs {
 		var row *Proof
-		dbTx := tx.Table(m.table).Where("block_number = ?", blockHeight).Find(&row)
+		dbTx := tx.Table(m.table).Where("block_number = ? AND status <> ?", blockHeight, Quarantined).Find(&row)
 		if dbTx.Error != nil {
 			return dbTx.Error
 		}
@@ -150,3 +191,56 @@ func (m *defaultProofModel) UpdateProofsInTransact(tx *gorm.DB, proofs map[int64
 	}
 	return nil
 }
+
+func (m *defaultProofModel) QuarantineProofInTransact(tx *gorm.DB, p *Proof) error {
+	dbTx := tx.Table(m.table).Where("id = ?", p.ID).Update("status", Quarantined)
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	if dbTx.RowsAffected == 0 {
+		return types.DbErrFailToUpdateProof
+	}
+	p.Status = Quarantined
+	return nil
+}
+
+// DeleteProofsInTransact deletes the proofs of the blocks from the height, the quarantined ones are kept.
+func (m *defaultProofModel) DeleteProofsInTransact(tx *gorm.DB, fromHeight int64) error {
+	dbTx := tx.Table(m.table).Unscoped().Where("block_number >= ? AND status <> ?", fromHeight, Quarantined).
+		Delete(&Proof{})
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	return nil
+}
+
+func (m *defaultProofModel) GetLatestProof() (p *Proof, err error) {
+	dbTx := m.DB.Table(m.table).Select("id, created_at, block_number, status").Where("status <> ?", Quarantined).
+		Order("block_number desc").Limit(1).Find(&p)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return p, nil
+}
+
+func (m *defaultProofModel) GetOldestNotSentProof() (p *Proof, err error) {
+	dbTx := m.DB.Table(m.table).Select("id, created_at, block_number, status").Where("status = ?", NotSent).
+		Order("block_number").Limit(1).Find(&p)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return p, nil
+}
+
+func (m *defaultProofModel) GetProofsCountBetween(from, to time.Time) (count int64, err error) {
+	dbTx := m.DB.Table(m.table).Where("status <> ? AND created_at BETWEEN ? AND ? AND deleted_at is NULL",
+		Quarantined, from, to).Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	}
+	return count, nil
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package proverworker
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	TableName = `prover_worker`
+)
+
+type (
+	ProverWorkerModel interface {
+		CreateProverWorkerTable() error
+		DropProverWorkerTable() error
+		RegisterProverWorker(worker *ProverWorker) error
+		UpdateProverWorkerHeartbeat(workerId string, heartbeatAt, expiredAt time.Time) error
+		GetProverWorkers() (workers []*ProverWorker, err error)
+		GetProverWorkerByWorkerId(workerId string) (worker *ProverWorker, err error)
+	}
+
+	defaultProverWorkerModel struct {
+		table string
+		DB    *gorm.DB
+	}
+
+	ProverWorker struct {
+		gorm.Model
+		WorkerId        string `gorm:"uniqueIndex"`
+		Host            string
+		BlockSizes      string
+		LastHeartbeatAt time.Time
+		// The worker is regarded as dead if no heartbeat is received before it.
+		ExpiredAt time.Time
+	}
+)
+
+func NewProverWorkerModel(db *gorm.DB) ProverWorkerModel {
+	return &defaultProverWorkerModel{
+		table: TableName,
+		DB:    db,
+	}
+}
+
+func (*ProverWorker) TableName() string {
+	return TableName
+}
+
+func (m *defaultProverWorkerModel) CreateProverWorkerTable() error {
+	return m.DB.AutoMigrate(ProverWorker{})
+}
+
+func (m *defaultProverWorkerModel) DropProverWorkerTable() error {
+	return m.DB.Migrator().DropTable(m.table)
+}
+
+// RegisterProverWorker creates the worker, or refreshes it when a worker with the same id restarts.
+func (m *defaultProverWorkerModel) RegisterProverWorker(worker *ProverWorker) error {
+	dbTx := m.DB.Table(m.table).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "worker_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"host", "block_sizes", "last_heartbeat_at", "expired_at", "updated_at"}),
+	}).Create(worker)
+	if dbTx.Error != nil {
+		return types.DbErrSqlOperation
+	}
+	return nil
+}
+
+func (m *defaultProverWorkerModel) UpdateProverWorkerHeartbeat(workerId string, heartbeatAt, expiredAt time.Time) error {
+	dbTx := m.DB.Table(m.table).Where("worker_id = ?", workerId).Updates(map[string]interface{}{
+		"last_heartbeat_at": heartbeatAt,
+		"expired_at":        expiredAt,
+		"updated_at":        heartbeatAt,
+	})
+	if dbTx.Error != nil {
+		return types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return types.DbErrNotFound
+	}
+	return nil
+}
+
+func (m *defaultProverWorkerModel) GetProverWorkers() (workers []*ProverWorker, err error) {
+	dbTx := m.DB.Table(m.table).Order("worker_id asc").Find(&workers)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return workers, nil
+}
+
+func (m *defaultProverWorkerModel) GetProverWorkerByWorkerId(workerId string) (worker *ProverWorker, err error) {
+	dbTx := m.DB.Table(m.table).Where("worker_id = ?", workerId).Find(&worker)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return worker, nil
+}
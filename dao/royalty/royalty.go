@@ -0,0 +1,131 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package royalty
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	RoyaltyTableName = `royalty`
+)
+
+type (
+	RoyaltyModel interface {
+		CreateRoyaltyTable() error
+		DropRoyaltyTable() error
+		GetCreatorEarnings(creatorAccountIndex int64, from, to time.Time) (earnings []*Earning, err error)
+		GetCollectionEarnings(creatorAccountIndex, collectionId int64, from, to time.Time) (earnings []*Earning, err error)
+		CreateRoyaltiesInTransact(tx *gorm.DB, royalties []*Royalty) error
+	}
+
+	defaultRoyaltyModel struct {
+		table string
+		DB    *gorm.DB
+	}
+
+	// Royalty is the payment to the nft creator in an AtomicMatch tx.
+	Royalty struct {
+		gorm.Model
+		TxHash              string `gorm:"uniqueIndex"`
+		CreatorAccountIndex int64  `gorm:"index:idx_royalty_creator"`
+		CollectionId        int64  `gorm:"index:idx_royalty_creator"`
+		NftIndex            int64
+		AssetId             int64
+		Amount              string `gorm:"type:numeric"`
+		BlockHeight         int64
+		BlockTime           time.Time `gorm:"index"`
+	}
+
+	// Earning is the summary of the royalties in the same group.
+	Earning struct {
+		CollectionId int64
+		NftIndex     int64
+		AssetId      int64
+		Count        int64
+		Amount       string
+	}
+)
+
+func NewRoyaltyModel(db *gorm.DB) RoyaltyModel {
+	return &defaultRoyaltyModel{
+		table: RoyaltyTableName,
+		DB:    db,
+	}
+}
+
+func (*Royalty) TableName() string {
+	return RoyaltyTableName
+}
+
+func (m *defaultRoyaltyModel) CreateRoyaltyTable() error {
+	return m.DB.AutoMigrate(Royalty{})
+}
+
+func (m *defaultRoyaltyModel) DropRoyaltyTable() error {
+	return m.DB.Migrator().DropTable(m.table)
+}
+
+// GetCreatorEarnings returns the earnings of the creator grouped by collection and asset, the NftIndex of
+// the earnings are types.NilNftIndex.
+func (m *defaultRoyaltyModel) GetCreatorEarnings(creatorAccountIndex int64, from, to time.Time) (earnings []*Earning, err error) {
+	dbTx := m.DB.Table(m.table).
+		Select("collection_id, ? as nft_index, asset_id, count(*) as count, sum(amount) as amount", types.NilNftIndex).
+		Where("creator_account_index = ? and block_time >= ? and block_time < ? and deleted_at is NULL", creatorAccountIndex, from, to).
+		Group("collection_id, asset_id").
+		Order("collection_id, asset_id").
+		Scan(&earnings)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return earnings, nil
+}
+
+// GetCollectionEarnings returns the earnings of the collection grouped by nft and asset.
+func (m *defaultRoyaltyModel) GetCollectionEarnings(creatorAccountIndex, collectionId int64, from, to time.Time) (earnings []*Earning, err error) {
+	dbTx := m.DB.Table(m.table).
+		Select("collection_id, nft_index, asset_id, count(*) as count, sum(amount) as amount").
+		Where("creator_account_index = ? and collection_id = ? and block_time >= ? and block_time < ? and deleted_at is NULL",
+			creatorAccountIndex, collectionId, from, to).
+		Group("collection_id, nft_index, asset_id").
+		Order("nft_index, asset_id").
+		Scan(&earnings)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return earnings, nil
+}
+
+func (m *defaultRoyaltyModel) CreateRoyaltiesInTransact(tx *gorm.DB, royalties []*Royalty) error {
+	dbTx := tx.Table(m.table).CreateInBatches(royalties, len(royalties))
+	if dbTx.Error != nil {
+		return dbTx.Error
+	}
+	if dbTx.RowsAffected != int64(len(royalties)) {
+		return types.DbErrFailToCreateRoyalty
+	}
+	return nil
+}
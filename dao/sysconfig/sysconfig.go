@@ -32,9 +32,11 @@ type (
 		CreateSysConfigTable() error
 		DropSysConfigTable() error
 		GetSysConfigByName(name string) (info *SysConfig, err error)
+		GetSysConfigs() (configs []*SysConfig, err error)
 		CreateSysConfigs(configs []*SysConfig) (rowsAffected int64, err error)
 		CreateSysConfigsInTransact(tx *gorm.DB, configs []*SysConfig) error
 		UpdateSysConfigsInTransact(tx *gorm.DB, configs []*SysConfig) error
+		UpdateSysConfig(config *SysConfig) error
 	}
 
 	defaultSysConfigModel struct {
@@ -80,6 +82,16 @@ func (m *defaultSysConfigModel) GetSysConfigByName(name string) (config *SysConf
 	return config, nil
 }
 
+func (m *defaultSysConfigModel) GetSysConfigs() (configs []*SysConfig, err error) {
+	dbTx := m.DB.Table(m.table).Order("name").Find(&configs)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return configs, nil
+}
+
 func (m *defaultSysConfigModel) CreateSysConfigs(configs []*SysConfig) (rowsAffected int64, err error) {
 	dbTx := m.DB.Table(m.table).CreateInBatches(configs, len(configs))
 	if dbTx.Error != nil {
@@ -114,3 +126,9 @@ func (m *defaultSysConfigModel) UpdateSysConfigsInTransact(tx *gorm.DB, configs
 	}
 	return nil
 }
+
+func (m *defaultSysConfigModel) UpdateSysConfig(config *SysConfig) error {
+	return m.DB.Transaction(func(tx *gorm.DB) error {
+		return m.UpdateSysConfigsInTransact(tx, []*SysConfig{config})
+	})
+}
@@ -18,6 +18,7 @@
 package tx
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -44,6 +45,8 @@ type (
 		GetTxs(limit int64, offset int64) (txList []*Tx, err error)
 		GetTxsByAccountIndex(accountIndex int64, limit int64, offset int64) (txList []*Tx, err error)
 		GetTxsCountByAccountIndex(accountIndex int64) (count int64, err error)
+		GetTxsByTxType(txType int64, accountIndex int64, limit int64, offset int64) (txList []*Tx, err error)
+		GetTxsCountByTxType(txType int64, accountIndex int64) (count int64, err error)
 		GetTxByHash(txHash string) (tx *Tx, err error)
 		GetTxsTotalCountBetween(from, to time.Time) (count int64, err error)
 		GetDistinctAccountsCountBetween(from, to time.Time) (count int64, err error)
@@ -57,7 +60,7 @@ type (
 	Tx struct {
 		gorm.Model
 		TxHash        string `gorm:"uniqueIndex"`
-		TxType        int64
+		TxType        int64  `gorm:"index:idx_tx_type_account"`
 		GasFee        string
 		GasFeeAssetId int64
 		TxStatus      int64
@@ -74,7 +77,7 @@ type (
 		TxDetails     []*TxDetail `gorm:"foreignKey:TxId"`
 		ExtraInfo     string
 		Memo          string
-		AccountIndex  int64
+		AccountIndex  int64 `gorm:"index:idx_tx_type_account"`
 		Nonce         int64
 		ExpiredAt     int64
 		TxIndex       int64
@@ -93,7 +96,12 @@ func (*Tx) TableName() string {
 }
 
 func (m *defaultTxModel) CreateTxTable() error {
-	return m.DB.AutoMigrate(Tx{})
+	err := m.DB.AutoMigrate(Tx{})
+	if err != nil {
+		return err
+	}
+	// The pipeline status and the statistics count the txs created in a time window.
+	return m.DB.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_created_at ON %s (created_at)", m.table, m.table)).Error
 }
 
 func (m *defaultTxModel) DropTxTable() error {
@@ -141,6 +149,35 @@ func (m *defaultTxModel) GetTxsCountByAccountIndex(accountIndex int64) (count in
 	return count, nil
 }
 
+// GetTxsByTxType returns the txs of the given type, the account index filter is ignored if it is types.NilAccountIndex.
+func (m *defaultTxModel) GetTxsByTxType(txType int64, accountIndex int64, limit int64, offset int64) (txList []*Tx, err error) {
+	dbTx := m.DB.Table(m.table).Where("tx_type = ?", txType)
+	if accountIndex != types.NilAccountIndex {
+		dbTx = dbTx.Where("account_index = ?", accountIndex)
+	}
+	dbTx = dbTx.Limit(int(limit)).Offset(int(offset)).Order("created_at desc").Find(&txList)
+	if dbTx.Error != nil {
+		return nil, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return nil, types.DbErrNotFound
+	}
+	return txList, nil
+}
+
+func (m *defaultTxModel) GetTxsCountByTxType(txType int64, accountIndex int64) (count int64, err error) {
+	dbTx := m.DB.Table(m.table).Where("tx_type = ?", txType)
+	if accountIndex != types.NilAccountIndex {
+		dbTx = dbTx.Where("account_index = ?", accountIndex)
+	}
+	dbTx = dbTx.Count(&count)
+	if dbTx.Error != nil {
+		return 0, types.DbErrSqlOperation
+	} else if dbTx.RowsAffected == 0 {
+		return 0, nil
+	}
+	return count, nil
+}
+
 func (m *defaultTxModel) GetTxByHash(txHash string) (tx *Tx, err error) {
 	dbTx := m.DB.Table(m.table).Where("tx_hash = ?", txHash).Find(&tx)
 	if dbTx.Error != nil {
@@ -109,6 +109,7 @@ CacheRedis:
 KeyPath:
   ProvingKeyPath: [${KEY_PATH}/zkbnb1.pk, ${KEY_PATH}/zkbnb10.pk]
   VerifyingKeyPath: [${KEY_PATH}/zkbnb1.vk, ${KEY_PATH}/zkbnb10.vk]
+  ManifestPath: ${KEY_PATH}/manifest.json
 
 BlockConfig:
   OptionalBlockSizes: [1, 10]
@@ -117,6 +118,8 @@ TreeDB:
   Driver: memorydb
 " > ${DEPLOY_PATH}/zkbnb/service/prover/etc/config.yaml
 
+cd ${DEPLOY_PATH}/zkbnb/ && go run ./cmd/zkbnb/main.go keys manifest --config ${DEPLOY_PATH}/zkbnb/service/prover/etc/config.yaml --output ${KEY_PATH}/manifest.json --verifier ${DEPLOY_PATH}/zkbnb-contract/contracts/ZkBNBVerifier.sol
+
 echo -e "
 go run ./cmd/zkbnb/main.go prover --config ${DEPLOY_PATH}/zkbnb/service/prover/etc/config.yaml
 " > run_prover.sh
@@ -223,6 +226,13 @@ ChainConfig:
   Sk: "acbaa269bd7573ff12361be4b97201aef019776ea13384681d4e5ba6a88367d9"
   GasLimit: 5000000
 
+KeyPath:
+  VerifyingKeyPath: [${KEY_PATH}/zkbnb1.vk, ${KEY_PATH}/zkbnb10.vk]
+  ManifestPath: ${KEY_PATH}/manifest.json
+
+BlockConfig:
+  OptionalBlockSizes: [1, 10]
+
 TreeDB:
   Driver: memorydb
 " > ${DEPLOY_PATH}/zkbnb/service/sender/etc/config.yaml
@@ -30,6 +30,7 @@ CacheRedis:
 KeyPath:
   ProvingKeyPath: [/server/.zkbnb/zkbnb1.pk]
   VerifyingKeyPath: [/server/.zkbnb/zkbnb1.vk]
+  ManifestPath: /server/.zkbnb/manifest.json
 
 BlockConfig:
   OptionalBlockSizes: [1]
@@ -103,6 +104,12 @@ ChainConfig:
   Sk: \"$SK\"
   GasLimit: 5000000
 
+KeyPath:
+  VerifyingKeyPath: [/server/.zkbnb/zkbnb1.vk]
+  ManifestPath: /server/.zkbnb/manifest.json
+
+BlockConfig:
+  OptionalBlockSizes: [1]
 " > ${CONFIG_PATH}/sender.yaml
 
 echo -e "
@@ -36,6 +36,18 @@ function prepare() {
     echo 'start verify_parse for ZkBNBVerifier ...'
     cd ${WORKDIR}/../service/prover/
     python3 verifier_parse.py ${KEY_PATH}/ZkbnbVerifier1.sol 1 ${WORKDIR}/dependency/zkbnb-contract/contracts/ZkbnbVerifier.sol
+
+    echo 'start generate key manifest ...'
+    echo -e "
+KeyPath:
+  ProvingKeyPath: [${KEY_PATH}/zkbnb1.pk]
+  VerifyingKeyPath: [${KEY_PATH}/zkbnb1.vk]
+  ManifestPath: ${KEY_PATH}/manifest.json
+
+BlockConfig:
+  OptionalBlockSizes: [1]
+" > ${KEY_PATH}/prover.yaml
+    cd ${WORKDIR}/.. && go run ./cmd/zkbnb/main.go keys manifest --config ${KEY_PATH}/prover.yaml --output ${KEY_PATH}/manifest.json
 }
 
 function getLatestBlockHeight() {
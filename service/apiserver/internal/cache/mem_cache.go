@@ -11,11 +11,16 @@ import (
 	blockdao "github.com/bnb-chain/zkbnb/dao/block"
 	"github.com/bnb-chain/zkbnb/dao/sysconfig"
 	"github.com/bnb-chain/zkbnb/dao/tx"
+	apitypes "github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	"github.com/bnb-chain/zkbnb/types"
 )
 
 const (
 	cacheDefaultExpiration    = time.Hour * 1   //gocache default expiration
 	cacheDefaultPurgeInterval = time.Minute * 5 // gocache purge interval
+	sysConfigExpiration       = time.Second * 5 // sys configs are changed at runtime by the admin api
+	pipelineExpiration        = time.Second * 5 // the pipeline status runs many count queries
+	assetStatusExpiration     = time.Second * 5 // assets are paused on L1 and synced by the monitor
 
 	AccountIndexNameKeyPrefix  = "in:" //key for cache: accountIndex -> accountName
 	AccountIndexPkKeyPrefix    = "ip:" //key for cache: accountIndex -> accountPk
@@ -32,8 +37,11 @@ const (
 	AssetIdNameKeyPrefix       = "IN:" //key for cache: assetId -> assetName
 	AssetByIdKeyPrefix         = "I:"  //key for cache: assetId -> asset
 	AssetBySymbolKeyPrefix     = "S:"  //key for cache: assetSymbol -> asset
+	AssetStatusKeyPrefix       = "AS:" //key for cache: assetId -> asset status
 	PriceKeyPrefix             = "p:"  //key for cache: symbol -> price
 	SysConfigKeyPrefix         = "s:"  //key for cache: configName -> sysconfig
+	NftMetadataKeyPrefix       = "m:"  //key for cache: nftContentHash -> nftMetadata
+	PipelineStatusKey          = "ps"  //key for cache: pipeline status
 )
 
 type fallback func() (interface{}, error)
@@ -65,8 +73,19 @@ func NewMemCache(accountModel accdao.AccountModel, assetModel assetdao.AssetMode
 	return memCache
 }
 
-func (m *MemCache) getWithSet(key string, duration time.Duration, f fallback) (interface{}, error) {
+// get looks up the key and records whether the named cache is hit.
+func (m *MemCache) get(cache, key string) (interface{}, bool) {
 	result, found := m.goCache.Get(key)
+	if found {
+		cacheRequestsMetric.Inc(cache, "hit")
+	} else {
+		cacheRequestsMetric.Inc(cache, "miss")
+	}
+	return result, found
+}
+
+func (m *MemCache) getWithSet(cache, key string, duration time.Duration, f fallback) (interface{}, error) {
+	result, found := m.get(cache, key)
 	if found {
 		return result, nil
 	}
@@ -86,7 +105,7 @@ func (m *MemCache) setAccount(accountIndex int64, accountName, accountPk string)
 }
 
 func (m *MemCache) GetAccountIndexByName(accountName string) (int64, error) {
-	index, found := m.goCache.Get(fmt.Sprintf("%s%s", AccountNameKeyPrefix, accountName))
+	index, found := m.get(cacheAccount, fmt.Sprintf("%s%s", AccountNameKeyPrefix, accountName))
 	if found {
 		return index.(int64), nil
 	}
@@ -99,7 +118,7 @@ func (m *MemCache) GetAccountIndexByName(accountName string) (int64, error) {
 }
 
 func (m *MemCache) GetAccountIndexByPk(accountPk string) (int64, error) {
-	index, found := m.goCache.Get(fmt.Sprintf("%s%s", AccountPkKeyPrefix, accountPk))
+	index, found := m.get(cacheAccount, fmt.Sprintf("%s%s", AccountPkKeyPrefix, accountPk))
 	if found {
 		return index.(int64), nil
 	}
@@ -112,7 +131,7 @@ func (m *MemCache) GetAccountIndexByPk(accountPk string) (int64, error) {
 }
 
 func (m *MemCache) GetAccountNameByIndex(accountIndex int64) (string, error) {
-	name, found := m.goCache.Get(fmt.Sprintf("%s%d", AccountIndexNameKeyPrefix, accountIndex))
+	name, found := m.get(cacheAccount, fmt.Sprintf("%s%d", AccountIndexNameKeyPrefix, accountIndex))
 	if found {
 		return name.(string), nil
 	}
@@ -125,7 +144,7 @@ func (m *MemCache) GetAccountNameByIndex(accountIndex int64) (string, error) {
 }
 
 func (m *MemCache) GetAccountPkByIndex(accountIndex int64) (string, error) {
-	pk, found := m.goCache.Get(fmt.Sprintf("%s%d", AccountIndexPkKeyPrefix, accountIndex))
+	pk, found := m.get(cacheAccount, fmt.Sprintf("%s%d", AccountIndexPkKeyPrefix, accountIndex))
 	if found {
 		return pk.(string), nil
 	}
@@ -139,7 +158,7 @@ func (m *MemCache) GetAccountPkByIndex(accountIndex int64) (string, error) {
 
 func (m *MemCache) GetAccountWithFallback(accountIndex int64, f fallback) (*accdao.Account, error) {
 	key := fmt.Sprintf("%s%d", AccountByIndexKeyPrefix, accountIndex)
-	a, err := m.getWithSet(key, m.accountExpiration, f)
+	a, err := m.getWithSet(cacheAccount, key, m.accountExpiration, f)
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +169,7 @@ func (m *MemCache) GetAccountWithFallback(accountIndex int64, f fallback) (*accd
 }
 
 func (m *MemCache) GetAccountTotalCountWiltFallback(f fallback) (int64, error) {
-	count, err := m.getWithSet(AccountCountKeyPrefix, m.accountExpiration, f)
+	count, err := m.getWithSet(cacheCount, AccountCountKeyPrefix, m.accountExpiration, f)
 	if err != nil {
 		return 0, err
 	}
@@ -159,7 +178,7 @@ func (m *MemCache) GetAccountTotalCountWiltFallback(f fallback) (int64, error) {
 
 func (m *MemCache) GetBlockByHeightWithFallback(blockHeight int64, f fallback) (*blockdao.Block, error) {
 	key := fmt.Sprintf("%s%d", BlockByHeightKeyPrefix, blockHeight)
-	b, err := m.getWithSet(key, m.blockExpiration, f)
+	b, err := m.getWithSet(cacheBlock, key, m.blockExpiration, f)
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +191,7 @@ func (m *MemCache) GetBlockByHeightWithFallback(blockHeight int64, f fallback) (
 
 func (m *MemCache) GetBlockByCommitmentWithFallback(blockCommitment string, f fallback) (*blockdao.Block, error) {
 	key := fmt.Sprintf("%s%s", BlockByCommitmentKeyPrefix, blockCommitment)
-	b, err := m.getWithSet(key, m.blockExpiration, f)
+	b, err := m.getWithSet(cacheBlock, key, m.blockExpiration, f)
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +203,7 @@ func (m *MemCache) GetBlockByCommitmentWithFallback(blockCommitment string, f fa
 }
 
 func (m *MemCache) GetBlockTotalCountWithFallback(f fallback) (int64, error) {
-	count, err := m.getWithSet(BlockCountKeyPrefix, m.blockExpiration, f)
+	count, err := m.getWithSet(cacheCount, BlockCountKeyPrefix, m.blockExpiration, f)
 	if err != nil {
 		return 0, err
 	}
@@ -193,7 +212,7 @@ func (m *MemCache) GetBlockTotalCountWithFallback(f fallback) (int64, error) {
 
 func (m *MemCache) GetTxByHashWithFallback(txHash string, f fallback) (*tx.Tx, error) {
 	key := fmt.Sprintf("%s%s", TxByHashKeyPrefix, txHash)
-	t, err := m.getWithSet(key, m.txExpiration, f)
+	t, err := m.getWithSet(cacheTx, key, m.txExpiration, f)
 	if err != nil {
 		return nil, err
 	}
@@ -201,7 +220,7 @@ func (m *MemCache) GetTxByHashWithFallback(txHash string, f fallback) (*tx.Tx, e
 }
 
 func (m *MemCache) GetTxTotalCountWithFallback(f fallback) (int64, error) {
-	count, err := m.getWithSet(TxCountKeyPrefix, m.txExpiration, f)
+	count, err := m.getWithSet(cacheCount, TxCountKeyPrefix, m.txExpiration, f)
 	if err != nil {
 		return 0, err
 	}
@@ -209,7 +228,7 @@ func (m *MemCache) GetTxTotalCountWithFallback(f fallback) (int64, error) {
 }
 
 func (m *MemCache) GetAssetTotalCountWithFallback(f fallback) (int64, error) {
-	count, err := m.getWithSet(AssetCountKeyKeyPrefix, m.txExpiration, f)
+	count, err := m.getWithSet(cacheCount, AssetCountKeyKeyPrefix, m.txExpiration, f)
 	if err != nil {
 		return 0, err
 	}
@@ -218,7 +237,7 @@ func (m *MemCache) GetAssetTotalCountWithFallback(f fallback) (int64, error) {
 
 func (m *MemCache) GetAssetByIdWithFallback(assetId int64, f fallback) (*assetdao.Asset, error) {
 	key := fmt.Sprintf("%s%d", AssetByIdKeyPrefix, assetId)
-	a, err := m.getWithSet(key, m.assetExpiration, f)
+	a, err := m.getWithSet(cacheAsset, key, m.assetExpiration, f)
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +253,7 @@ func (m *MemCache) GetAssetByIdWithFallback(assetId int64, f fallback) (*assetda
 
 func (m *MemCache) GetAssetBySymbolWithFallback(assetSymbol string, f fallback) (*assetdao.Asset, error) {
 	key := fmt.Sprintf("%s%s", AssetBySymbolKeyPrefix, assetSymbol)
-	a, err := m.getWithSet(key, m.assetExpiration, f)
+	a, err := m.getWithSet(cacheAsset, key, m.assetExpiration, f)
 	if err != nil {
 		return nil, err
 	}
@@ -250,7 +269,7 @@ func (m *MemCache) GetAssetBySymbolWithFallback(assetSymbol string, f fallback)
 
 func (m *MemCache) GetAssetNameById(assetId int64) (string, error) {
 	key := fmt.Sprintf("%s%d", AssetIdNameKeyPrefix, assetId)
-	name, found := m.goCache.Get(key)
+	name, found := m.get(cacheAsset, key)
 	if found {
 		return name.(string), nil
 	}
@@ -263,9 +282,26 @@ func (m *MemCache) GetAssetNameById(assetId int64) (string, error) {
 	return asset.AssetName, nil
 }
 
+// GetAssetStatusById returns the status of the asset, it is cached much shorter than the asset
+// so that a paused asset is refused soon after the pause is synced.
+func (m *MemCache) GetAssetStatusById(assetId int64) (uint32, error) {
+	key := fmt.Sprintf("%s%d", AssetStatusKeyPrefix, assetId)
+	status, err := m.getWithSet(cacheAsset, key, assetStatusExpiration, func() (interface{}, error) {
+		asset, err := m.assetModel.GetAssetById(assetId)
+		if err != nil {
+			return nil, err
+		}
+		return asset.Status, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return status.(uint32), nil
+}
+
 func (m *MemCache) GetPriceWithFallback(symbol string, f fallback) (float64, error) {
 	key := fmt.Sprintf("%s%s", PriceKeyPrefix, symbol)
-	price, err := m.getWithSet(key, m.priceExpiration, f)
+	price, err := m.getWithSet(cachePrice, key, m.priceExpiration, f)
 	if err != nil {
 		return 0, err
 	}
@@ -274,9 +310,30 @@ func (m *MemCache) GetPriceWithFallback(symbol string, f fallback) (float64, err
 
 func (m *MemCache) GetSysConfigWithFallback(configName string, f fallback) (*sysconfig.SysConfig, error) {
 	key := fmt.Sprintf("%s%s", SysConfigKeyPrefix, configName)
-	c, err := m.getWithSet(key, gocache.DefaultExpiration, f)
+	c, err := m.getWithSet(cacheSysConfig, key, sysConfigExpiration, f)
 	if err != nil {
 		return nil, err
 	}
 	return c.(*sysconfig.SysConfig), nil
 }
+
+func (m *MemCache) DeleteSysConfig(configName string) {
+	m.goCache.Delete(fmt.Sprintf("%s%s", SysConfigKeyPrefix, configName))
+}
+
+func (m *MemCache) GetNftMetadataWithFallback(contentHash string, f fallback) (*types.NftMetadata, error) {
+	key := fmt.Sprintf("%s%s", NftMetadataKeyPrefix, contentHash)
+	metadata, err := m.getWithSet(cacheNftMetadata, key, gocache.DefaultExpiration, f)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.(*types.NftMetadata), nil
+}
+
+func (m *MemCache) GetPipelineStatusWithFallback(f fallback) (*apitypes.PipelineStatus, error) {
+	status, err := m.getWithSet(cachePipeline, PipelineStatusKey, pipelineExpiration, f)
+	if err != nil {
+		return nil, err
+	}
+	return status.(*apitypes.PipelineStatus), nil
+}
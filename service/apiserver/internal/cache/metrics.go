@@ -0,0 +1,27 @@
+package cache
+
+import (
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+const (
+	metricNamespace = "zkbnb"
+
+	cacheAccount     = "account"
+	cacheBlock       = "block"
+	cacheTx          = "tx"
+	cacheAsset       = "asset"
+	cacheCount       = "count"
+	cachePrice       = "price"
+	cacheSysConfig   = "sys_config"
+	cacheNftMetadata = "nft_metadata"
+	cachePipeline    = "pipeline"
+)
+
+var cacheRequestsMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+	Namespace: metricNamespace,
+	Subsystem: "apiserver",
+	Name:      "cache_requests_total",
+	Help:      "Number of lookups in the memory cache, the result is hit or miss.",
+	Labels:    []string{"cache", "result"},
+})
@@ -4,6 +4,8 @@ import (
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/rest"
+
+	"github.com/bnb-chain/zkbnb/common/storage"
 )
 
 type Config struct {
@@ -24,4 +26,19 @@ type Config struct {
 		TxExpiration      int
 		PriceExpiration   int
 	}
+	// Storage of the uploaded nft metadata, it's the local directory ./data/nft-metadata if it is not set.
+	NftMetadata struct {
+		Storage storage.Config `json:",optional"`
+	} `json:",optional"`
+	// The admin api is authorized by the api keys, all the requests are rejected if no key is configured.
+	Admin struct {
+		ApiKeys []AdminApiKey `json:",optional"`
+	} `json:",optional"`
+}
+
+type AdminApiKey struct {
+	// Name of the operator, it's recorded in the audit logs.
+	Name string
+	// Hex encoded sha256 hash of the api key.
+	KeyHash string
 }
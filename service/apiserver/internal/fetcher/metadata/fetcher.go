@@ -0,0 +1,77 @@
+package metadata
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/bnb-chain/zkbnb/common/storage"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/cache"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+var (
+	ErrInvalidContentHash  = errors.New("invalid content hash")
+	ErrContentHashMismatch = errors.New("content hash mismatches metadata")
+)
+
+// Fetcher resolves the metadata documents of nfts by their content hashes, the documents are kept in the storage
+// and the resolved metadata are cached in memory.
+type Fetcher interface {
+	GetNftMetadata(contentHash string) (*types.NftMetadata, error)
+	PutNftMetadata(contentHash string, doc []byte) (*types.NftMetadata, error)
+}
+
+func NewFetcher(memCache *cache.MemCache, storage storage.Storage) Fetcher {
+	return &fetcher{
+		memCache: memCache,
+		storage:  storage,
+	}
+}
+
+type fetcher struct {
+	memCache *cache.MemCache
+	storage  storage.Storage
+}
+
+func (f *fetcher) GetNftMetadata(contentHash string) (*types.NftMetadata, error) {
+	key, err := storageKey(contentHash)
+	if err != nil {
+		return nil, err
+	}
+	return f.memCache.GetNftMetadataWithFallback(key, func() (interface{}, error) {
+		doc, err := f.storage.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		return types.ParseNftMetadata(doc)
+	})
+}
+
+func (f *fetcher) PutNftMetadata(contentHash string, doc []byte) (*types.NftMetadata, error) {
+	key, err := storageKey(contentHash)
+	if err != nil {
+		return nil, err
+	}
+	if types.ComputeNftContentHash(doc) != key {
+		return nil, ErrContentHashMismatch
+	}
+	metadata, err := types.ParseNftMetadata(doc)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.storage.Put(key, doc); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// storageKey normalizes the content hash, so that the hashes with or without 0x prefix are the same.
+func storageKey(contentHash string) (string, error) {
+	hash := common.FromHex(contentHash)
+	if len(hash) != common.HashLength || bytes.Equal(hash, common.Hash{}.Bytes()) {
+		return "", ErrInvalidContentHash
+	}
+	return common.Bytes2Hex(hash), nil
+}
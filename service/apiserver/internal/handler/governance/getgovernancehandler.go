@@ -0,0 +1,22 @@
+package governance
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/logic/governance"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+)
+
+func GetGovernanceHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := governance.NewGetGovernanceLogic(r.Context(), svcCtx)
+		resp, err := l.GetGovernance()
+		if err != nil {
+			httpx.Error(w, err)
+		} else {
+			httpx.OkJson(w, resp)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package pipeline
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/logic/pipeline"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+)
+
+func GetPipelineStatusHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := pipeline.NewGetPipelineStatusLogic(r.Context(), svcCtx)
+		resp, err := l.GetPipelineStatus()
+		if err != nil {
+			httpx.Error(w, err)
+		} else {
+			httpx.OkJson(w, resp)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package prover
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/logic/prover"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+)
+
+func GetProverStatusHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := prover.NewGetProverStatusLogic(r.Context(), svcCtx)
+		resp, err := l.GetProverStatus()
+		if err != nil {
+			httpx.Error(w, err)
+		} else {
+			httpx.OkJson(w, resp)
+		}
+	}
+}
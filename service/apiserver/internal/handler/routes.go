@@ -5,12 +5,18 @@ import (
 	"net/http"
 
 	account "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/account"
+	admin "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/admin"
 	asset "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/asset"
 	block "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/block"
+	governance "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/governance"
 	info "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/info"
 	nft "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/nft"
+	offer "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/offer"
 	pair "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/pair"
+	pipeline "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/pipeline"
+	prover "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/prover"
 	root "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/root"
+	royalty "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/royalty"
 	transaction "github.com/bnb-chain/zkbnb/service/apiserver/internal/handler/transaction"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
 
@@ -200,6 +206,156 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/api/v1/accountNfts",
 				Handler: nft.GetAccountNftsHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/collections",
+				Handler: nft.GetCollectionsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/collectionNfts",
+				Handler: nft.GetCollectionNftsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/nft",
+				Handler: nft.GetNftHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/nftHistories",
+				Handler: nft.GetNftHistoriesHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/nftMetadata",
+				Handler: nft.GetNftMetadataHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/api/v1/nftMetadata",
+				Handler: nft.UploadNftMetadataHandler(serverCtx),
+			},
 		},
 	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/api/v1/offer",
+				Handler: offer.SendOfferHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/offer",
+				Handler: offer.GetOfferHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/offers",
+				Handler: offer.GetOffersHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/searchOffers",
+				Handler: offer.SearchOffersHandler(serverCtx),
+			},
+		},
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/creatorEarnings",
+				Handler: royalty.GetCreatorEarningsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/collectionEarnings",
+				Handler: royalty.GetCollectionEarningsHandler(serverCtx),
+			},
+		},
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/proverStatus",
+				Handler: prover.GetProverStatusHandler(serverCtx),
+			},
+		},
+	)
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/governance",
+				Handler: governance.GetGovernanceHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/governanceEvents",
+				Handler: governance.GetGovernanceEventsHandler(serverCtx),
+			},
+		},
+	)
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/pipelineStatus",
+				Handler: pipeline.GetPipelineStatusHandler(serverCtx),
+			},
+		},
+	)
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.AdminAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/admin/sysConfigs",
+					Handler: admin.GetSysConfigsHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/admin/sysConfig",
+					Handler: admin.UpdateSysConfigHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/admin/pauseSendTx",
+					Handler: admin.PauseSendTxHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/admin/resumeSendTx",
+					Handler: admin.ResumeSendTxHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/admin/drainMempool",
+					Handler: admin.DrainMempoolHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/admin/sealBlock",
+					Handler: admin.SealBlockHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/admin/status",
+					Handler: admin.GetAdminStatusHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/admin/auditLogs",
+					Handler: admin.GetAuditLogsHandler(serverCtx),
+				},
+			}...,
+		),
+	)
 }
@@ -0,0 +1,31 @@
+package royalty
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/logic/royalty"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func GetCreatorEarningsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ReqGetCreatorEarnings
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.Error(w, err)
+			return
+		}
+
+		l := royalty.NewGetCreatorEarningsLogic(r.Context(), svcCtx)
+		resp, err := l.GetCreatorEarnings(&req)
+		if err != nil {
+			httpx.Error(w, err)
+		} else if req.Format == royalty.FormatCsv {
+			royalty.WriteEarningsCsv(w, resp)
+		} else {
+			httpx.OkJson(w, resp)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/dao/auditlog"
+	"github.com/bnb-chain/zkbnb/dao/sysconfig"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/middleware"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+// sendTxTypes are the tx types accepted by sendTx.
+var sendTxTypes = []uint32{
+	types2.TxTypeTransfer,
+	types2.TxTypeSwap,
+	types2.TxTypeAddLiquidity,
+	types2.TxTypeRemoveLiquidity,
+	types2.TxTypeWithdraw,
+	types2.TxTypeCreateCollection,
+	types2.TxTypeMintNft,
+	types2.TxTypeTransferNft,
+	types2.TxTypeAtomicMatch,
+	types2.TxTypeCancelOffer,
+	types2.TxTypeWithdrawNft,
+}
+
+type updatableSysConfig struct {
+	valueType string
+	comment   string
+	validate  func(svcCtx *svc.ServiceContext, value string) error
+}
+
+// updatableSysConfigs are the sys configs which can be updated by the admin api, the others are
+// initialized at deployment or synced from L1.
+var updatableSysConfigs = map[string]*updatableSysConfig{
+	types2.SysGasFee: {
+		valueType: "string",
+		comment:   "based on BNB",
+		validate: func(_ *svc.ServiceContext, value string) error {
+			gasFee, ok := new(big.Int).SetString(value, 10)
+			if !ok || gasFee.Sign() < 0 {
+				return fmt.Errorf("invalid gas fee: %s", value)
+			}
+			return nil
+		},
+	},
+	types2.TreasuryAccountIndex: {
+		valueType: "int",
+		comment:   "treasury index",
+		validate:  validateAccountIndex,
+	},
+	types2.GasAccountIndex: {
+		valueType: "int",
+		comment:   "gas index",
+		validate:  validateAccountIndex,
+	},
+	types2.OptionalBlockSizes: {
+		valueType: "[]int",
+		comment:   "block sizes used by the committer, a subset of its configured block sizes",
+		validate: func(_ *svc.ServiceContext, value string) error {
+			var blockSizes []int
+			if err := json.Unmarshal([]byte(value), &blockSizes); err != nil || len(blockSizes) == 0 {
+				return fmt.Errorf("invalid block sizes: %s", value)
+			}
+			for i, blockSize := range blockSizes {
+				if blockSize <= 0 || (i > 0 && blockSize <= blockSizes[i-1]) {
+					return fmt.Errorf("block sizes should be positive and ascending: %s", value)
+				}
+			}
+			return nil
+		},
+	},
+}
+
+func validateAccountIndex(svcCtx *svc.ServiceContext, value string) error {
+	accountIndex, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || accountIndex < 0 {
+		return fmt.Errorf("invalid account index: %s", value)
+	}
+	if _, err = svcCtx.AccountModel.GetAccountByIndex(accountIndex); err != nil {
+		return fmt.Errorf("account %d is not found", accountIndex)
+	}
+	return nil
+}
+
+// audit records the operation of the operator who sends the request.
+func audit(ctx context.Context, svcCtx *svc.ServiceContext, action string, params interface{}, err error) {
+	paramsBytes, _ := json.Marshal(params)
+	auditLog := &auditlog.AuditLog{
+		Operator: middleware.Operator(ctx),
+		Action:   action,
+		Params:   string(paramsBytes),
+	}
+	if err != nil {
+		auditLog.Error = err.Error()
+	}
+	if err := svcCtx.AuditLogModel.CreateAuditLog(auditLog); err != nil {
+		logx.Errorf("fail to create audit log: %v, err: %s", auditLog, err.Error())
+	}
+}
+
+// saveSysConfig creates or updates the sys config, the cached one is dropped so that the change takes
+// effect at once in this server, the other servers and services read it again within seconds.
+func saveSysConfig(svcCtx *svc.ServiceContext, name, value, valueType, comment string) (*sysconfig.SysConfig, error) {
+	sysConfig, err := svcCtx.SysConfigModel.GetSysConfigByName(name)
+	if err == types2.DbErrNotFound {
+		sysConfig = &sysconfig.SysConfig{
+			Name:      name,
+			Value:     value,
+			ValueType: valueType,
+			Comment:   comment,
+		}
+		_, err = svcCtx.SysConfigModel.CreateSysConfigs([]*sysconfig.SysConfig{sysConfig})
+	} else if err == nil {
+		sysConfig.Value = value
+		err = svcCtx.SysConfigModel.UpdateSysConfig(sysConfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+	svcCtx.MemCache.DeleteSysConfig(name)
+	return sysConfig, nil
+}
+
+func getPausedTxTypes(svcCtx *svc.ServiceContext) ([]uint32, error) {
+	pausedTxTypes := make([]uint32, 0)
+	sysConfig, err := svcCtx.SysConfigModel.GetSysConfigByName(types2.PausedTxTypes)
+	if err == types2.DbErrNotFound {
+		return pausedTxTypes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(sysConfig.Value), &pausedTxTypes)
+	if err != nil {
+		return nil, err
+	}
+	return pausedTxTypes, nil
+}
+
+// updatePausedTxTypes pauses or resumes the tx types in sendTx.
+func updatePausedTxTypes(svcCtx *svc.ServiceContext, txTypes []uint32, paused bool) error {
+	for _, txType := range txTypes {
+		if !isSendTxType(txType) {
+			return types2.AppErrInvalidParam.RefineError(fmt.Sprintf("invalid tx type %d", txType))
+		}
+	}
+	pausedTxTypes, err := getPausedTxTypes(svcCtx)
+	if err != nil {
+		return types2.AppErrInternal
+	}
+	pausedTxTypeSet := make(map[uint32]bool, len(pausedTxTypes))
+	for _, txType := range pausedTxTypes {
+		pausedTxTypeSet[txType] = true
+	}
+	for _, txType := range txTypes {
+		pausedTxTypeSet[txType] = paused
+	}
+	pausedTxTypes = make([]uint32, 0, len(pausedTxTypeSet))
+	for txType, paused := range pausedTxTypeSet {
+		if paused {
+			pausedTxTypes = append(pausedTxTypes, txType)
+		}
+	}
+	sort.Slice(pausedTxTypes, func(i, j int) bool { return pausedTxTypes[i] < pausedTxTypes[j] })
+	pausedTxTypesBytes, err := json.Marshal(pausedTxTypes)
+	if err != nil {
+		return types2.AppErrInternal
+	}
+	_, err = saveSysConfig(svcCtx, types2.PausedTxTypes, string(pausedTxTypesBytes), "[]int",
+		"tx types which are not accepted by sendTx")
+	if err != nil {
+		return types2.AppErrInternal
+	}
+	return nil
+}
+
+func isSendTxType(txType uint32) bool {
+	for _, sendTxType := range sendTxTypes {
+		if txType == sendTxType {
+			return true
+		}
+	}
+	return false
+}
+
+// requestSealBlock requests the committer to seal the current block.
+func requestSealBlock(svcCtx *svc.ServiceContext, requestedAt int64) error {
+	_, err := saveSysConfig(svcCtx, types2.SealBlockRequest, strconv.FormatInt(requestedAt, 10), "int",
+		"time in milliseconds when the committer is requested to seal the current block")
+	if err != nil {
+		return types2.AppErrInternal
+	}
+	return nil
+}
+
+func getStatus(svcCtx *svc.ServiceContext) (*types.AdminStatus, error) {
+	pausedTxTypes, err := getPausedTxTypes(svcCtx)
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+	status := &types.AdminStatus{
+		PausedTxTypes: pausedTxTypes,
+	}
+	status.PendingMempoolTxs, err = svcCtx.MempoolModel.GetMempoolTxsTotalCount()
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+	status.CurrentHeight, err = svcCtx.BlockModel.GetCurrentBlockHeight()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, types2.AppErrInternal
+	}
+	status.VerifiedHeight, err = svcCtx.BlockModel.GetLatestVerifiedHeight()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, types2.AppErrInternal
+	}
+	sealBlockRequest, err := svcCtx.SysConfigModel.GetSysConfigByName(types2.SealBlockRequest)
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, types2.AppErrInternal
+	}
+	if sealBlockRequest != nil {
+		status.SealBlockRequestedAt, _ = strconv.ParseInt(sealBlockRequest.Value, 10, 64)
+	}
+	return status, nil
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+type DrainMempoolLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDrainMempoolLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DrainMempoolLogic {
+	return &DrainMempoolLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// DrainMempool stops accepting new txs and seals the current block, the pending txs are still executed
+// by the committer. The tx types are resumed by ResumeSendTx.
+func (l *DrainMempoolLogic) DrainMempool() (resp *types.AdminStatus, err error) {
+	defer func() {
+		audit(l.ctx, l.svcCtx, "drainMempool", nil, err)
+	}()
+
+	if err := updatePausedTxTypes(l.svcCtx, sendTxTypes, true); err != nil {
+		return nil, err
+	}
+	if err := requestSealBlock(l.svcCtx, time.Now().UnixMilli()); err != nil {
+		return nil, err
+	}
+	return getStatus(l.svcCtx)
+}
@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+type GetAdminStatusLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetAdminStatusLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetAdminStatusLogic {
+	return &GetAdminStatusLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetAdminStatusLogic) GetAdminStatus() (resp *types.AdminStatus, err error) {
+	return getStatus(l.svcCtx)
+}
@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type GetAuditLogsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetAuditLogsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetAuditLogsLogic {
+	return &GetAuditLogsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetAuditLogsLogic) GetAuditLogs(req *types.ReqGetRange) (resp *types.AuditLogs, err error) {
+	total, err := l.svcCtx.AuditLogModel.GetAuditLogsTotalCount()
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+
+	resp = &types.AuditLogs{
+		AuditLogs: make([]*types.AuditLog, 0),
+		Total:     uint32(total),
+	}
+	if total == 0 || total <= int64(req.Offset) {
+		return resp, nil
+	}
+
+	auditLogs, err := l.svcCtx.AuditLogModel.GetAuditLogs(int64(req.Limit), int64(req.Offset))
+	if err != nil {
+		if err == types2.DbErrNotFound {
+			return resp, nil
+		}
+		return nil, types2.AppErrInternal
+	}
+	for _, auditLog := range auditLogs {
+		resp.AuditLogs = append(resp.AuditLogs, &types.AuditLog{
+			Id:        uint32(auditLog.ID),
+			Operator:  auditLog.Operator,
+			Action:    auditLog.Action,
+			Params:    auditLog.Params,
+			Error:     auditLog.Error,
+			CreatedAt: auditLog.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
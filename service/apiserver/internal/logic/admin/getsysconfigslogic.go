@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type GetSysConfigsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetSysConfigsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetSysConfigsLogic {
+	return &GetSysConfigsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetSysConfigsLogic) GetSysConfigs() (resp *types.AdminSysConfigs, err error) {
+	resp = &types.AdminSysConfigs{
+		SysConfigs: make([]*types.AdminSysConfig, 0),
+	}
+	sysConfigs, err := l.svcCtx.SysConfigModel.GetSysConfigs()
+	if err != nil {
+		if err == types2.DbErrNotFound {
+			return resp, nil
+		}
+		return nil, types2.AppErrInternal
+	}
+	for _, sysConfig := range sysConfigs {
+		_, updatable := updatableSysConfigs[sysConfig.Name]
+		resp.SysConfigs = append(resp.SysConfigs, &types.AdminSysConfig{
+			Name:      sysConfig.Name,
+			Value:     sysConfig.Value,
+			ValueType: sysConfig.ValueType,
+			Comment:   sysConfig.Comment,
+			Updatable: updatable,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+type PauseSendTxLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPauseSendTxLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PauseSendTxLogic {
+	return &PauseSendTxLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *PauseSendTxLogic) PauseSendTx(req *types.ReqUpdateSendTx) (resp *types.AdminStatus, err error) {
+	defer func() {
+		audit(l.ctx, l.svcCtx, "pauseSendTx", req, err)
+	}()
+
+	if err := updatePausedTxTypes(l.svcCtx, req.TxTypes, true); err != nil {
+		return nil, err
+	}
+	return getStatus(l.svcCtx)
+}
@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+type ResumeSendTxLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewResumeSendTxLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ResumeSendTxLogic {
+	return &ResumeSendTxLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ResumeSendTxLogic) ResumeSendTx(req *types.ReqUpdateSendTx) (resp *types.AdminStatus, err error) {
+	defer func() {
+		audit(l.ctx, l.svcCtx, "resumeSendTx", req, err)
+	}()
+
+	if err := updatePausedTxTypes(l.svcCtx, req.TxTypes, false); err != nil {
+		return nil, err
+	}
+	return getStatus(l.svcCtx)
+}
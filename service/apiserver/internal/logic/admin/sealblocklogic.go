@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+type SealBlockLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSealBlockLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SealBlockLogic {
+	return &SealBlockLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SealBlock requests the committer to seal the current block if it has any tx.
+func (l *SealBlockLogic) SealBlock() (resp *types.AdminStatus, err error) {
+	defer func() {
+		audit(l.ctx, l.svcCtx, "sealBlock", nil, err)
+	}()
+
+	if err := requestSealBlock(l.svcCtx, time.Now().UnixMilli()); err != nil {
+		return nil, err
+	}
+	return getStatus(l.svcCtx)
+}
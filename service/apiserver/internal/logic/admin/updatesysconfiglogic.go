@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type UpdateSysConfigLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateSysConfigLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateSysConfigLogic {
+	return &UpdateSysConfigLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateSysConfigLogic) UpdateSysConfig(req *types.ReqUpdateSysConfig) (resp *types.AdminSysConfig, err error) {
+	defer func() {
+		audit(l.ctx, l.svcCtx, "updateSysConfig", req, err)
+	}()
+
+	updatable, ok := updatableSysConfigs[req.Name]
+	if !ok {
+		return nil, types2.AppErrInvalidParam.RefineError("sys config is not updatable: " + req.Name)
+	}
+	if err := updatable.validate(l.svcCtx, req.Value); err != nil {
+		return nil, types2.AppErrInvalidParam.RefineError(err.Error())
+	}
+	sysConfig, err := saveSysConfig(l.svcCtx, req.Name, req.Value, updatable.valueType, updatable.comment)
+	if err != nil {
+		logx.Errorf("fail to save sys config %s, err: %s", req.Name, err.Error())
+		return nil, types2.AppErrInternal
+	}
+	return &types.AdminSysConfig{
+		Name:      sysConfig.Name,
+		Value:     sysConfig.Value,
+		ValueType: sysConfig.ValueType,
+		Comment:   sysConfig.Comment,
+		Updatable: true,
+	}, nil
+}
@@ -54,6 +54,7 @@ func (l *GetAssetsLogic) GetAssets(req *types.ReqGetRange) (resp *types.Assets,
 			Symbol:     asset.AssetSymbol,
 			Address:    asset.L1Address,
 			IsGasAsset: asset.IsGasAsset,
+			Status:     asset.Status,
 		})
 	}
 	return resp, nil
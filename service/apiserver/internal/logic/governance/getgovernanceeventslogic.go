@@ -0,0 +1,85 @@
+package governance
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/dao/l1syncedblock"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+// l1EventInfo is the event recorded in the synced l1 block by the monitor.
+type l1EventInfo struct {
+	EventType uint8
+	TxHash    string
+}
+
+// governanceEventNames are the names of the governance event types recorded by the monitor.
+var governanceEventNames = map[uint8]string{
+	4: "NewAsset",
+	5: "NewGovernor",
+	6: "NewAssetGovernance",
+	7: "ValidatorStatusUpdate",
+	8: "AssetPausedUpdate",
+}
+
+type GetGovernanceEventsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetGovernanceEventsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetGovernanceEventsLogic {
+	return &GetGovernanceEventsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetGovernanceEventsLogic) GetGovernanceEvents(req *types.ReqGetRange) (resp *types.GovernanceEvents, err error) {
+	resp = &types.GovernanceEvents{
+		Events: make([]*types.GovernanceEvent, 0),
+	}
+	blocks, err := l.svcCtx.L1SyncedBlockModel.GetL1SyncedBlocksWithEvents(l1syncedblock.TypeGovernance)
+	if err != nil {
+		if err == types2.DbErrNotFound {
+			return resp, nil
+		}
+		return nil, types2.AppErrInternal
+	}
+
+	events := make([]*types.GovernanceEvent, 0)
+	for _, block := range blocks {
+		var eventInfos []*l1EventInfo
+		err = json.Unmarshal([]byte(block.BlockInfo), &eventInfos)
+		if err != nil {
+			logx.Errorf("fail to unmarshal l1 synced block %d, err: %s", block.L1BlockHeight, err.Error())
+			return nil, types2.AppErrInternal
+		}
+		// the events of a block are recorded in the order of the logs
+		for i := len(eventInfos) - 1; i >= 0; i-- {
+			events = append(events, &types.GovernanceEvent{
+				SyncedL1BlockHeight: block.L1BlockHeight,
+				Type:                uint32(eventInfos[i].EventType),
+				Name:                governanceEventNames[eventInfos[i].EventType],
+				L1TxHash:            eventInfos[i].TxHash,
+			})
+		}
+	}
+
+	resp.Total = uint32(len(events))
+	if int(req.Offset) >= len(events) {
+		return resp, nil
+	}
+	end := int(req.Offset + req.Limit)
+	if end > len(events) {
+		end = len(events)
+	}
+	resp.Events = events[req.Offset:end]
+	return resp, nil
+}
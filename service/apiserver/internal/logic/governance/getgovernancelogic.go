@@ -0,0 +1,83 @@
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+// validatorInfo is the validator stored in the sys config by the monitor.
+type validatorInfo struct {
+	Address  string
+	IsActive bool
+}
+
+type GetGovernanceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetGovernanceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetGovernanceLogic {
+	return &GetGovernanceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetGovernanceLogic) GetGovernance() (resp *types.Governance, err error) {
+	resp = &types.Governance{
+		Validators: make([]*types.Validator, 0),
+	}
+	resp.Governor, err = l.getSysConfigValue(types2.Governor)
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+	resp.AssetGovernance, err = l.getSysConfigValue(types2.AssetGovernanceContract)
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+
+	validatorsValue, err := l.getSysConfigValue(types2.Validators)
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+	if validatorsValue == "" {
+		return resp, nil
+	}
+	validators := make(map[string]*validatorInfo)
+	err = json.Unmarshal([]byte(validatorsValue), &validators)
+	if err != nil {
+		logx.Errorf("fail to unmarshal validators: %s, err: %s", validatorsValue, err.Error())
+		return nil, types2.AppErrInternal
+	}
+	for address, validator := range validators {
+		resp.Validators = append(resp.Validators, &types.Validator{
+			Address:  address,
+			IsActive: validator.IsActive,
+		})
+	}
+	sort.Slice(resp.Validators, func(i, j int) bool {
+		return resp.Validators[i].Address < resp.Validators[j].Address
+	})
+	return resp, nil
+}
+
+// getSysConfigValue reads the sys config from the database, as the governance is changed at any time.
+func (l *GetGovernanceLogic) getSysConfigValue(name string) (string, error) {
+	sysConfig, err := l.svcCtx.SysConfigModel.GetSysConfigByName(name)
+	if err == types2.DbErrNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return sysConfig.Value, nil
+}
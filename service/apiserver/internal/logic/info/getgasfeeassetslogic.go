@@ -5,6 +5,7 @@ import (
 
 	"github.com/zeromicro/go-zero/core/logx"
 
+	asset2 "github.com/bnb-chain/zkbnb/dao/asset"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
 	types2 "github.com/bnb-chain/zkbnb/types"
@@ -33,6 +34,10 @@ func (l *GetGasFeeAssetsLogic) GetGasFeeAssets() (resp *types.GasFeeAssets, err
 	}
 
 	for _, asset := range assets {
+		// the paused assets can't be used to pay the gas fee
+		if asset.Status != asset2.StatusActive {
+			continue
+		}
 		resp.Assets = append(resp.Assets, types.Asset{
 			Id:         asset.AssetId,
 			Name:       asset.AssetName,
@@ -40,6 +45,7 @@ func (l *GetGasFeeAssetsLogic) GetGasFeeAssets() (resp *types.GasFeeAssets, err
 			Symbol:     asset.AssetSymbol,
 			Address:    asset.L1Address,
 			IsGasAsset: asset.IsGasAsset,
+			Status:     asset.Status,
 		})
 	}
 	return resp, nil
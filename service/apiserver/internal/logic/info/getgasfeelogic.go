@@ -45,6 +45,14 @@ func (l *GetGasFeeLogic) GetGasFee(req *types.ReqGetGasFee) (*types.GasFee, erro
 		logx.Errorf("not gas asset id: %d", asset.AssetId)
 		return nil, types2.AppErrInvalidGasAsset
 	}
+	status, err := l.svcCtx.MemCache.GetAssetStatusById(int64(req.AssetId))
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+	if status != asset2.StatusActive {
+		logx.Errorf("paused gas asset id: %d", asset.AssetId)
+		return nil, types2.AppErrAssetPaused
+	}
 	sysGasFee, err := l.svcCtx.MemCache.GetSysConfigWithFallback(types2.SysGasFee, func() (interface{}, error) {
 		return l.svcCtx.SysConfigModel.GetSysConfigByName(types2.SysGasFee)
 	})
@@ -89,6 +89,7 @@ func (l *GetAccountNftsLogic) GetAccountNfts(req *types.ReqGetAccountNfts) (resp
 			L1TokenId:           nft.NftL1TokenId,
 			CreatorTreasuryRate: nft.CreatorTreasuryRate,
 			CollectionId:        nft.CollectionId,
+			Metadata:            getNftMetadata(l.svcCtx, nft.NftContentHash),
 		})
 	}
 	return resp, nil
@@ -0,0 +1,66 @@
+package nft
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type GetCollectionNftsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetCollectionNftsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCollectionNftsLogic {
+	return &GetCollectionNftsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetCollectionNftsLogic) GetCollectionNfts(req *types.ReqGetCollectionNfts) (resp *types.Nfts, err error) {
+	resp = &types.Nfts{
+		Nfts: make([]*types.Nft, 0),
+	}
+
+	// Collection id is assigned by the creator's collection nonce, so the creator is required to identify a collection.
+	total, err := l.svcCtx.NftModel.GetNftsCountByCollectionId(int64(req.AccountIndex), int64(req.CollectionId))
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+
+	resp.Total = total
+	if total == 0 || total <= int64(req.Offset) {
+		return resp, nil
+	}
+
+	nfts, err := l.svcCtx.NftModel.GetNftsByCollectionId(int64(req.AccountIndex), int64(req.CollectionId), int64(req.Limit), int64(req.Offset))
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+
+	for _, nft := range nfts {
+		creatorName, _ := l.svcCtx.MemCache.GetAccountNameByIndex(nft.CreatorAccountIndex)
+		ownerName, _ := l.svcCtx.MemCache.GetAccountNameByIndex(nft.OwnerAccountIndex)
+		resp.Nfts = append(resp.Nfts, &types.Nft{
+			Index:               nft.NftIndex,
+			CreatorAccountIndex: nft.CreatorAccountIndex,
+			CreatorAccountName:  creatorName,
+			OwnerAccountIndex:   nft.OwnerAccountIndex,
+			OwnerAccountName:    ownerName,
+			ContentHash:         nft.NftContentHash,
+			L1Address:           nft.NftL1Address,
+			L1TokenId:           nft.NftL1TokenId,
+			CreatorTreasuryRate: nft.CreatorTreasuryRate,
+			CollectionId:        nft.CollectionId,
+			Metadata:            getNftMetadata(l.svcCtx, nft.NftContentHash),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,97 @@
+package nft
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	queryAll = "all"
+)
+
+type GetCollectionsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetCollectionsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCollectionsLogic {
+	return &GetCollectionsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetCollectionsLogic) GetCollections(req *types.ReqGetCollections) (resp *types.Collections, err error) {
+	resp = &types.Collections{
+		Collections: make([]*types.Collection, 0),
+	}
+
+	accountIndex := types2.NilAccountIndex
+	switch req.By {
+	case queryAll:
+	case queryByAccountIndex:
+		accountIndex, err = strconv.ParseInt(req.Value, 10, 64)
+		if err != nil || accountIndex < 0 {
+			return nil, types2.AppErrInvalidParam.RefineError("invalid value for account_index")
+		}
+	case queryByAccountName:
+		accountIndex, err = l.svcCtx.MemCache.GetAccountIndexByName(req.Value)
+	case queryByAccountPk:
+		accountIndex, err = l.svcCtx.MemCache.GetAccountIndexByPk(req.Value)
+	default:
+		return nil, types2.AppErrInvalidParam.RefineError("param by should be all|account_index|account_name|account_pk")
+	}
+
+	if err != nil {
+		if err == types2.DbErrNotFound {
+			return resp, nil
+		}
+		return nil, types2.AppErrInternal
+	}
+
+	total, err := l.svcCtx.TxModel.GetTxsCountByTxType(types2.TxTypeCreateCollection, accountIndex)
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+
+	resp.Total = total
+	if total == 0 || total <= int64(req.Offset) {
+		return resp, nil
+	}
+
+	txs, err := l.svcCtx.TxModel.GetTxsByTxType(types2.TxTypeCreateCollection, accountIndex, int64(req.Limit), int64(req.Offset))
+	if err != nil {
+		if err == types2.DbErrNotFound {
+			return resp, nil
+		}
+		return nil, types2.AppErrInternal
+	}
+
+	for _, tx := range txs {
+		txInfo, err := types2.ParseCreateCollectionTxInfo(tx.TxInfo)
+		if err != nil {
+			logx.Errorf("parse create collection tx failed: %s", err.Error())
+			return nil, types2.AppErrInternal
+		}
+		accountName, _ := l.svcCtx.MemCache.GetAccountNameByIndex(tx.AccountIndex)
+		resp.Collections = append(resp.Collections, &types.Collection{
+			Id:           txInfo.CollectionId,
+			AccountIndex: tx.AccountIndex,
+			AccountName:  accountName,
+			Name:         txInfo.Name,
+			Introduction: txInfo.Introduction,
+			TxHash:       tx.TxHash,
+			BlockHeight:  tx.BlockHeight,
+			CreatedAt:    tx.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,64 @@
+package nft
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type GetNftHistoriesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetNftHistoriesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetNftHistoriesLogic {
+	return &GetNftHistoriesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetNftHistoriesLogic) GetNftHistories(req *types.ReqGetNftHistories) (resp *types.NftHistories, err error) {
+	resp = &types.NftHistories{
+		Histories: make([]*types.NftHistory, 0),
+	}
+
+	total, err := l.svcCtx.NftHistoryModel.GetNftHistoriesCount(req.NftIndex)
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+
+	resp.Total = total
+	if total == 0 || total <= int64(req.Offset) {
+		return resp, nil
+	}
+
+	histories, err := l.svcCtx.NftHistoryModel.GetNftHistories(req.NftIndex, int64(req.Limit), int64(req.Offset))
+	if err != nil {
+		if err == types2.DbErrNotFound {
+			return resp, nil
+		}
+		return nil, types2.AppErrInternal
+	}
+
+	for _, history := range histories {
+		ownerName, _ := l.svcCtx.MemCache.GetAccountNameByIndex(history.OwnerAccountIndex)
+		resp.Histories = append(resp.Histories, &types.NftHistory{
+			NftIndex:            history.NftIndex,
+			CreatorAccountIndex: history.CreatorAccountIndex,
+			OwnerAccountIndex:   history.OwnerAccountIndex,
+			OwnerAccountName:    ownerName,
+			ContentHash:         history.NftContentHash,
+			CollectionId:        history.CollectionId,
+			BlockHeight:         history.L2BlockHeight,
+			CreatedAt:           history.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,74 @@
+package nft
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/common/storage"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/fetcher/metadata"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type GetNftMetadataLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetNftMetadataLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetNftMetadataLogic {
+	return &GetNftMetadataLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetNftMetadataLogic) GetNftMetadata(req *types.ReqGetNftMetadata) (resp *types.NftMetadata, err error) {
+	nftMetadata, err := l.svcCtx.MetadataFetcher.GetNftMetadata(req.ContentHash)
+	if err != nil {
+		if err == metadata.ErrInvalidContentHash {
+			return nil, types2.AppErrInvalidParam.RefineError("invalid content_hash")
+		}
+		if err == storage.ErrNotFound {
+			return nil, types2.AppErrNotFound
+		}
+		return nil, types2.AppErrInternal
+	}
+	return convertNftMetadata(req.ContentHash, nftMetadata), nil
+}
+
+// getNftMetadata returns nil when the metadata of the nft has not been uploaded.
+func getNftMetadata(svcCtx *svc.ServiceContext, contentHash string) *types.NftMetadata {
+	nftMetadata, err := svcCtx.MetadataFetcher.GetNftMetadata(contentHash)
+	if err != nil {
+		if err != storage.ErrNotFound && err != metadata.ErrInvalidContentHash {
+			logx.Errorf("fail to get nft metadata: %s, err: %s", contentHash, err.Error())
+		}
+		return nil
+	}
+	return convertNftMetadata(contentHash, nftMetadata)
+}
+
+func convertNftMetadata(contentHash string, nftMetadata *types2.NftMetadata) *types.NftMetadata {
+	resp := &types.NftMetadata{
+		ContentHash: contentHash,
+		Name:        nftMetadata.Name,
+		Description: nftMetadata.Description,
+		Image:       nftMetadata.Image,
+		Attributes:  make([]*types.NftAttribute, 0, len(nftMetadata.Attributes)),
+	}
+	for _, attribute := range nftMetadata.Attributes {
+		if attribute == nil {
+			continue
+		}
+		resp.Attributes = append(resp.Attributes, &types.NftAttribute{
+			TraitType: attribute.TraitType,
+			Value:     fmt.Sprint(attribute.Value),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,44 @@
+package nft
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/fetcher/metadata"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type UploadNftMetadataLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUploadNftMetadataLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UploadNftMetadataLogic {
+	return &UploadNftMetadataLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UploadNftMetadataLogic) UploadNftMetadata(req *types.ReqUploadNftMetadata) (resp *types.NftMetadata, err error) {
+	nftMetadata, err := l.svcCtx.MetadataFetcher.PutNftMetadata(req.ContentHash, []byte(req.Metadata))
+	if err != nil {
+		switch err {
+		case metadata.ErrInvalidContentHash:
+			return nil, types2.AppErrInvalidParam.RefineError("invalid content_hash")
+		case metadata.ErrContentHashMismatch:
+			return nil, types2.AppErrInvalidParam.RefineError("content_hash mismatches metadata")
+		case types2.JsonErrUnmarshal:
+			return nil, types2.AppErrInvalidParam.RefineError("invalid metadata")
+		default:
+			logx.Errorf("fail to put nft metadata: %s, err: %s", req.ContentHash, err.Error())
+			return nil, types2.AppErrInternal
+		}
+	}
+	return convertNftMetadata(req.ContentHash, nftMetadata), nil
+}
@@ -0,0 +1,37 @@
+package offer
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type GetOfferLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetOfferLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetOfferLogic {
+	return &GetOfferLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetOfferLogic) GetOffer(req *types.ReqGetOffer) (resp *types.Offer, err error) {
+	offer, err := l.svcCtx.OfferModel.GetOffer(int64(req.AccountIndex), int64(req.OfferId))
+	if err != nil {
+		if err == types2.DbErrNotFound {
+			return nil, types2.AppErrNotFound
+		}
+		return nil, types2.AppErrInternal
+	}
+	return convertOffer(l.svcCtx, offer, time.Now().UnixMilli()), nil
+}
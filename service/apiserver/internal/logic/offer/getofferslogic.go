@@ -0,0 +1,158 @@
+package offer
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/dao/offer"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	queryByNftIndex     = "nft_index"
+	queryByCollectionId = "collection_id"
+	queryByAccountIndex = "account_index"
+
+	offerTypeAll  = "all"
+	offerTypeBuy  = "buy"
+	offerTypeSell = "sell"
+
+	offerStatusAll       = "all"
+	offerStatusPending   = "pending"
+	offerStatusFinalized = "finalized"
+	offerStatusCanceled  = "canceled"
+	offerStatusExpired   = "expired"
+)
+
+type GetOffersLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetOffersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetOffersLogic {
+	return &GetOffersLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetOffersLogic) GetOffers(req *types.ReqGetOffers) (resp *types.Offers, err error) {
+	value, err := strconv.ParseInt(req.Value, 10, 64)
+	if err != nil || value < 0 {
+		return nil, types2.AppErrInvalidParam.RefineError("invalid value for " + req.By)
+	}
+
+	now := time.Now().UnixMilli()
+	query := offer.NewOfferQuery()
+	switch req.By {
+	case queryByNftIndex:
+		query.NftIndex = value
+	case queryByCollectionId:
+		query.CollectionId = value
+	case queryByAccountIndex:
+		query.AccountIndex = value
+	default:
+		return nil, types2.AppErrInvalidParam.RefineError("param by should be nft_index|collection_id|account_index")
+	}
+	if err = fillOfferQuery(query, req.Type, req.Status, now); err != nil {
+		return nil, err
+	}
+
+	return getOffers(l.svcCtx, query, int64(req.Offset), int64(req.Limit), now)
+}
+
+func fillOfferQuery(query *offer.OfferQuery, offerType, status string, now int64) error {
+	switch offerType {
+	case offerTypeAll:
+	case offerTypeBuy:
+		query.OfferType = types2.BuyOfferType
+	case offerTypeSell:
+		query.OfferType = types2.SellOfferType
+	default:
+		return types2.AppErrInvalidParam.RefineError("param type should be all|buy|sell")
+	}
+
+	switch status {
+	case offerStatusAll:
+	case offerStatusPending:
+		query.Status = offer.StatusPending
+		query.ExpiredAfter = now
+	case offerStatusFinalized:
+		query.Status = offer.StatusFinalized
+	case offerStatusCanceled:
+		query.Status = offer.StatusCanceled
+	case offerStatusExpired:
+		query.Status = offer.StatusPending
+		query.ExpiredBefore = now
+	default:
+		return types2.AppErrInvalidParam.RefineError("param status should be all|pending|finalized|canceled|expired")
+	}
+	return nil
+}
+
+func getOffers(svcCtx *svc.ServiceContext, query *offer.OfferQuery, offset, limit, now int64) (*types.Offers, error) {
+	resp := &types.Offers{
+		Offers: make([]*types.Offer, 0),
+	}
+
+	total, err := svcCtx.OfferModel.GetOffersCount(query)
+	if err != nil {
+		return nil, types2.AppErrInternal
+	}
+
+	resp.Total = total
+	if total == 0 || total <= offset {
+		return resp, nil
+	}
+
+	offers, err := svcCtx.OfferModel.GetOffers(query, limit, offset)
+	if err != nil {
+		if err == types2.DbErrNotFound {
+			return resp, nil
+		}
+		return nil, types2.AppErrInternal
+	}
+	for _, o := range offers {
+		resp.Offers = append(resp.Offers, convertOffer(svcCtx, o, now))
+	}
+	return resp, nil
+}
+
+func convertOffer(svcCtx *svc.ServiceContext, o *offer.Offer, now int64) *types.Offer {
+	status := offerStatusPending
+	switch o.Status {
+	case offer.StatusFinalized:
+		status = offerStatusFinalized
+	case offer.StatusCanceled:
+		status = offerStatusCanceled
+	default:
+		if o.ExpiredAt < now {
+			status = offerStatusExpired
+		}
+	}
+
+	accountName, _ := svcCtx.MemCache.GetAccountNameByIndex(o.AccountIndex)
+	return &types.Offer{
+		Type:         o.OfferType,
+		OfferId:      o.OfferId,
+		AccountIndex: o.AccountIndex,
+		AccountName:  accountName,
+		NftIndex:     o.NftIndex,
+		CollectionId: o.CollectionId,
+		AssetId:      o.AssetId,
+		AssetAmount:  o.AssetAmount,
+		ListedAt:     o.ListedAt,
+		ExpiredAt:    o.ExpiredAt,
+		TreasuryRate: o.TreasuryRate,
+		Info:         o.OfferInfo,
+		Status:       status,
+		TxHash:       o.TxHash,
+	}
+}
@@ -0,0 +1,49 @@
+package offer
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/dao/offer"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+type SearchOffersLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSearchOffersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SearchOffersLogic {
+	return &SearchOffersLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SearchOffersLogic) SearchOffers(req *types.ReqSearchOffers) (resp *types.Offers, err error) {
+	now := time.Now().UnixMilli()
+	query := offer.NewOfferQuery()
+	// Negative values mean that the filters are not set.
+	if req.AccountIndex >= 0 {
+		query.AccountIndex = req.AccountIndex
+	}
+	if req.NftIndex >= 0 {
+		query.NftIndex = req.NftIndex
+	}
+	if req.CollectionId >= 0 {
+		query.CollectionId = req.CollectionId
+	}
+	if req.AssetId >= 0 {
+		query.AssetId = req.AssetId
+	}
+	if err = fillOfferQuery(query, req.Type, req.Status, now); err != nil {
+		return nil, err
+	}
+
+	return getOffers(l.svcCtx, query, int64(req.Offset), int64(req.Limit), now)
+}
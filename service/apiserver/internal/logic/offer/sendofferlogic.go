@@ -0,0 +1,100 @@
+package offer
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/core/executor"
+	"github.com/bnb-chain/zkbnb/dao/offer"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type SendOfferLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSendOfferLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SendOfferLogic {
+	return &SendOfferLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SendOfferLogic) SendOffer(req *types.ReqSendOffer) (resp *types.Offer, err error) {
+	offerInfo, err := types2.ParseOfferTxInfo(req.OfferInfo)
+	if err != nil {
+		return nil, types2.AppErrInvalidTx
+	}
+	if err := offerInfo.Validate(); err != nil {
+		return nil, types2.AppErrInvalidTxField.RefineError(err.Error())
+	}
+	now := time.Now().UnixMilli()
+	if offerInfo.ExpiredAt < now {
+		return nil, types2.AppErrInvalidTxField.RefineError("invalid ExpiredAt")
+	}
+
+	account, err := l.svcCtx.StateFetcher.GetLatestAccount(offerInfo.AccountIndex)
+	if err != nil {
+		if err == types2.DbErrNotFound {
+			return nil, types2.AppErrInvalidTxField.RefineError("invalid AccountIndex")
+		}
+		return nil, types2.AppErrInternal
+	}
+	if err := offerInfo.VerifySignature(account.PublicKey); err != nil {
+		return nil, types2.AppErrInvalidTxField.RefineError(err.Error())
+	}
+	offerAsset := account.AssetInfo[offerInfo.OfferId/executor.OfferPerAsset]
+	if offerAsset != nil && offerAsset.OfferCanceledOrFinalized != nil &&
+		offerAsset.OfferCanceledOrFinalized.Bit(int(offerInfo.OfferId%executor.OfferPerAsset)) == 1 {
+		return nil, types2.AppErrInvalidTxField.RefineError("invalid offer id, already confirmed or canceled")
+	}
+
+	nft, err := l.svcCtx.StateFetcher.GetLatestNft(offerInfo.NftIndex)
+	if err != nil {
+		if err == types2.DbErrNotFound {
+			return nil, types2.AppErrInvalidTxField.RefineError("invalid NftIndex")
+		}
+		return nil, types2.AppErrInternal
+	}
+	if offerInfo.Type == types2.SellOfferType && nft.OwnerAccountIndex != offerInfo.AccountIndex {
+		return nil, types2.AppErrInvalidTxField.RefineError("seller is not owner")
+	}
+	if offerInfo.Type == types2.BuyOfferType && nft.OwnerAccountIndex == offerInfo.AccountIndex {
+		return nil, types2.AppErrInvalidTxField.RefineError("buyer is owner")
+	}
+
+	_, err = l.svcCtx.OfferModel.GetOffer(offerInfo.AccountIndex, offerInfo.OfferId)
+	if err == nil {
+		return nil, types2.AppErrInvalidTxField.RefineError("offer already exists")
+	} else if err != types2.DbErrNotFound {
+		return nil, types2.AppErrInternal
+	}
+
+	newOffer := &offer.Offer{
+		OfferType:    offerInfo.Type,
+		OfferId:      offerInfo.OfferId,
+		AccountIndex: offerInfo.AccountIndex,
+		NftIndex:     offerInfo.NftIndex,
+		CollectionId: nft.CollectionId,
+		AssetId:      offerInfo.AssetId,
+		AssetAmount:  offerInfo.AssetAmount.String(),
+		ListedAt:     offerInfo.ListedAt,
+		ExpiredAt:    offerInfo.ExpiredAt,
+		TreasuryRate: offerInfo.TreasuryRate,
+		OfferInfo:    req.OfferInfo,
+		Status:       offer.StatusPending,
+	}
+	if err := l.svcCtx.OfferModel.CreateOffer(newOffer); err != nil {
+		logx.Errorf("fail to create offer: %v, err: %s", newOffer, err.Error())
+		return nil, types2.AppErrInternal
+	}
+
+	return convertOffer(l.svcCtx, newOffer, now), nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/zeromicro/go-zero/core/logx"
 
 	"github.com/bnb-chain/zkbnb/common/chain"
+	asset2 "github.com/bnb-chain/zkbnb/dao/asset"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
 	types2 "github.com/bnb-chain/zkbnb/types"
@@ -51,6 +52,17 @@ func (l *GetSwapAmountLogic) GetSwapAmount(req *types.ReqGetSwapAmount) (*types.
 		return nil, types2.AppErrInvalidParam.RefineError("invalid AssetId")
 	}
 
+	for _, assetId := range []int64{liquidity.AssetAId, liquidity.AssetBId} {
+		status, err := l.svcCtx.MemCache.GetAssetStatusById(assetId)
+		if err != nil {
+			return nil, types2.AppErrInternal
+		}
+		if status != asset2.StatusActive {
+			logx.Errorf("paused asset id: %d", assetId)
+			return nil, types2.AppErrAssetPaused
+		}
+	}
+
 	if liquidity.AssetA.Cmp(big.NewInt(0)) == 0 || liquidity.AssetB.Cmp(big.NewInt(0)) == 0 {
 		logx.Errorf("invalid liquidity asset amount: %v", liquidity)
 		return nil, types2.AppErrInvalidParam.RefineError("invalid PairIndex, empty liquidity or invalid pair")
@@ -0,0 +1,317 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/dao/block"
+	"github.com/bnb-chain/zkbnb/dao/l1rolluptx"
+	"github.com/bnb-chain/zkbnb/dao/l1syncedblock"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	StageMempool           = "mempool"
+	StageBlock             = "block"
+	StageWitness           = "witness"
+	StageProof             = "proof"
+	StageL1CommitSent      = "l1_commit_sent"
+	StageL1CommitConfirmed = "l1_commit_confirmed"
+	StageL1Verified        = "l1_verified"
+	StageL1Synced          = "l1_synced"
+
+	// The throughput is the number of items per minute which passed the stage in the window.
+	throughputWindow = 10 * time.Minute
+)
+
+type GetPipelineStatusLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+
+	now           time.Time
+	currentHeight int64
+}
+
+func NewGetPipelineStatusLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetPipelineStatusLogic {
+	return &GetPipelineStatusLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetPipelineStatus reports the stages in the order the blocks pass them. The lag of a stage is the number
+// of blocks it is behind the last block, except that the mempool is measured in pending txs and the lag of
+// the synced l1 blocks is not known without the l1 head. The status is cached for a few seconds.
+func (l *GetPipelineStatusLogic) GetPipelineStatus() (resp *types.PipelineStatus, err error) {
+	return l.svcCtx.MemCache.GetPipelineStatusWithFallback(func() (interface{}, error) {
+		return l.getPipelineStatus()
+	})
+}
+
+func (l *GetPipelineStatusLogic) getPipelineStatus() (resp *types.PipelineStatus, err error) {
+	l.now = time.Now()
+	l.currentHeight, err = l.svcCtx.BlockModel.GetCurrentBlockHeight()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, types2.AppErrInternal
+	}
+
+	stages := []func() (*types.PipelineStage, error){
+		l.mempoolStage,
+		l.blockStage,
+		l.witnessStage,
+		l.proofStage,
+		l.l1CommitSentStage,
+		l.l1CommitConfirmedStage,
+		l.l1VerifiedStage,
+		l.l1SyncedStage,
+	}
+	resp = &types.PipelineStatus{
+		Stages: make([]*types.PipelineStage, 0, len(stages)),
+	}
+	for _, getStage := range stages {
+		stage, err := getStage()
+		if err != nil {
+			logx.Errorf("fail to get pipeline status, err: %v", err)
+			return nil, types2.AppErrInternal
+		}
+		resp.Stages = append(resp.Stages, stage)
+	}
+	return resp, nil
+}
+
+func (l *GetPipelineStatusLogic) mempoolStage() (*types.PipelineStage, error) {
+	pendingCount, err := l.svcCtx.MempoolModel.GetMempoolTxsTotalCount()
+	if err != nil {
+		return nil, err
+	}
+	executedCount, err := l.svcCtx.TxModel.GetTxsTotalCountBetween(l.windowStart(), l.now)
+	if err != nil {
+		return nil, err
+	}
+	stage := &types.PipelineStage{
+		Name:       StageMempool,
+		Height:     pendingCount,
+		Lag:        pendingCount,
+		Throughput: throughput(executedCount),
+	}
+	oldest, err := l.svcCtx.MempoolModel.GetOldestPendingMempoolTx()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, err
+	}
+	if oldest != nil {
+		stage.OldestStuckItem = l.newItem(oldest.TxHash, 0, oldest.CreatedAt)
+	}
+	return stage, nil
+}
+
+func (l *GetPipelineStatusLogic) blockStage() (*types.PipelineStage, error) {
+	count, err := l.svcCtx.BlockModel.GetBlocksCountBetween(l.windowStart(), l.now)
+	if err != nil {
+		return nil, err
+	}
+	stage := &types.PipelineStage{
+		Name:       StageBlock,
+		Height:     l.currentHeight,
+		Throughput: throughput(count),
+	}
+	// The oldest block waiting for its witness.
+	witnessHeight, err := l.svcCtx.BlockWitnessModel.GetLatestBlockWitnessHeight()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, err
+	}
+	if witnessHeight < l.currentHeight {
+		b, err := l.svcCtx.BlockModel.GetBlockByHeightWithoutTx(witnessHeight + 1)
+		if err != nil {
+			return nil, err
+		}
+		stage.OldestStuckItem = l.newItem(b.BlockCommitment, b.BlockHeight, b.CreatedAt)
+	}
+	return stage, nil
+}
+
+func (l *GetPipelineStatusLogic) witnessStage() (*types.PipelineStage, error) {
+	height, err := l.svcCtx.BlockWitnessModel.GetLatestBlockWitnessHeight()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, err
+	}
+	count, err := l.svcCtx.BlockWitnessModel.GetBlockWitnessesCountBetween(l.windowStart(), l.now)
+	if err != nil {
+		return nil, err
+	}
+	stage := l.newStage(StageWitness, height, count)
+	// The oldest witness waiting for its proof, the key is the prover worker which holds it.
+	oldest, err := l.svcCtx.BlockWitnessModel.GetOldestUnprovedBlockWitness()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, err
+	}
+	if oldest != nil {
+		stage.OldestStuckItem = l.newItem(oldest.WorkerId, oldest.Height, oldest.CreatedAt)
+	}
+	return stage, nil
+}
+
+func (l *GetPipelineStatusLogic) proofStage() (*types.PipelineStage, error) {
+	var height int64
+	latest, err := l.svcCtx.ProofModel.GetLatestProof()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, err
+	}
+	if latest != nil {
+		height = latest.BlockNumber
+	}
+	count, err := l.svcCtx.ProofModel.GetProofsCountBetween(l.windowStart(), l.now)
+	if err != nil {
+		return nil, err
+	}
+	stage := l.newStage(StageProof, height, count)
+	// The oldest proof waiting to be submitted to l1.
+	oldest, err := l.svcCtx.ProofModel.GetOldestNotSentProof()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, err
+	}
+	if oldest != nil {
+		stage.OldestStuckItem = l.newItem("", oldest.BlockNumber, oldest.CreatedAt)
+	}
+	return stage, nil
+}
+
+func (l *GetPipelineStatusLogic) l1CommitSentStage() (*types.PipelineStage, error) {
+	handled, pending, err := l.latestRollupTxs(l1rolluptx.TxTypeCommit)
+	if err != nil {
+		return nil, err
+	}
+	var height int64
+	if pending != nil {
+		height = pending.L2BlockHeight
+	} else if handled != nil {
+		height = handled.L2BlockHeight
+	}
+	count, err := l.svcCtx.L1RollupTxModel.GetL1RollupTxsCountBetween(l1rolluptx.TxTypeCommit, l.windowStart(), l.now)
+	if err != nil {
+		return nil, err
+	}
+	stage := l.newStage(StageL1CommitSent, height, count)
+	// The commit tx waiting for its confirmation.
+	if pending != nil {
+		stage.OldestStuckItem = l.newItem(pending.L1TxHash, pending.L2BlockHeight, pending.CreatedAt)
+	}
+	return stage, nil
+}
+
+func (l *GetPipelineStatusLogic) l1CommitConfirmedStage() (*types.PipelineStage, error) {
+	handled, _, err := l.latestRollupTxs(l1rolluptx.TxTypeCommit)
+	if err != nil {
+		return nil, err
+	}
+	var height int64
+	if handled != nil {
+		height = handled.L2BlockHeight
+	}
+	count, err := l.svcCtx.BlockModel.GetCommittedBlocksCountBetween(l.windowStart(), l.now)
+	if err != nil {
+		return nil, err
+	}
+	stage := l.newStage(StageL1CommitConfirmed, height, count)
+	// The oldest committed block waiting for its verification.
+	oldest, err := l.svcCtx.BlockModel.GetOldestBlockByStatus(block.StatusCommitted)
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, err
+	}
+	if oldest != nil {
+		stage.OldestStuckItem = l.newItem(oldest.CommittedTxHash, oldest.BlockHeight, time.Unix(oldest.CommittedAt, 0))
+	}
+	return stage, nil
+}
+
+func (l *GetPipelineStatusLogic) l1VerifiedStage() (*types.PipelineStage, error) {
+	height, err := l.svcCtx.BlockModel.GetLatestVerifiedHeight()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, err
+	}
+	count, err := l.svcCtx.BlockModel.GetVerifiedBlocksCountBetween(l.windowStart(), l.now)
+	if err != nil {
+		return nil, err
+	}
+	stage := l.newStage(StageL1Verified, height, count)
+	// The verify tx waiting for its confirmation.
+	_, pending, err := l.latestRollupTxs(l1rolluptx.TxTypeVerifyAndExecute)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		stage.OldestStuckItem = l.newItem(pending.L1TxHash, pending.L2BlockHeight, pending.CreatedAt)
+	}
+	return stage, nil
+}
+
+func (l *GetPipelineStatusLogic) l1SyncedStage() (*types.PipelineStage, error) {
+	stage := &types.PipelineStage{
+		Name: StageL1Synced,
+	}
+	latest, err := l.svcCtx.L1SyncedBlockModel.GetLatestL1BlockByType(l1syncedblock.TypeGeneric)
+	if err == types2.DbErrNotFound {
+		return stage, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	stage.Height = latest.L1BlockHeight
+	// The synced blocks are recorded once per round, the throughput is the l1 blocks synced since the
+	// last round before the window.
+	previous, err := l.svcCtx.L1SyncedBlockModel.GetLatestL1BlockByTypeBefore(l1syncedblock.TypeGeneric, l.windowStart())
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, err
+	}
+	if previous != nil {
+		stage.Throughput = throughput(latest.L1BlockHeight - previous.L1BlockHeight)
+	}
+	return stage, nil
+}
+
+func (l *GetPipelineStatusLogic) latestRollupTxs(txType int64) (handled, pending *l1rolluptx.L1RollupTx, err error) {
+	handled, err = l.svcCtx.L1RollupTxModel.GetLatestHandledTx(txType)
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, nil, err
+	}
+	pending, err = l.svcCtx.L1RollupTxModel.GetLatestPendingTx(txType)
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, nil, err
+	}
+	return handled, pending, nil
+}
+
+func (l *GetPipelineStatusLogic) windowStart() time.Time {
+	return l.now.Add(-throughputWindow)
+}
+
+func (l *GetPipelineStatusLogic) newStage(name string, height int64, count int64) *types.PipelineStage {
+	lag := l.currentHeight - height
+	if lag < 0 {
+		lag = 0
+	}
+	return &types.PipelineStage{
+		Name:       name,
+		Height:     height,
+		Lag:        lag,
+		Throughput: throughput(count),
+	}
+}
+
+func (l *GetPipelineStatusLogic) newItem(key string, height int64, since time.Time) *types.PipelineItem {
+	return &types.PipelineItem{
+		Key:      key,
+		Height:   height,
+		Since:    since.UnixMilli(),
+		Duration: l.now.Sub(since).Milliseconds(),
+	}
+}
+
+func throughput(count int64) float64 {
+	return float64(count) / throughputWindow.Minutes()
+}
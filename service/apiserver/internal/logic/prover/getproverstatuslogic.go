@@ -0,0 +1,64 @@
+package prover
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type GetProverStatusLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetProverStatusLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetProverStatusLogic {
+	return &GetProverStatusLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetProverStatusLogic) GetProverStatus() (resp *types.ProverStatus, err error) {
+	resp = &types.ProverStatus{
+		Workers: make([]*types.ProverWorker, 0),
+		Jobs:    make([]*types.ProverJob, 0),
+	}
+	now := time.Now()
+
+	workers, err := l.svcCtx.ProverWorkerModel.GetProverWorkers()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, types2.AppErrInternal
+	}
+	for _, worker := range workers {
+		resp.Workers = append(resp.Workers, &types.ProverWorker{
+			WorkerId:        worker.WorkerId,
+			Host:            worker.Host,
+			BlockSizes:      worker.BlockSizes,
+			Alive:           worker.ExpiredAt.After(now),
+			LastHeartbeatAt: worker.LastHeartbeatAt.UnixMilli(),
+		})
+	}
+
+	witnesses, err := l.svcCtx.BlockWitnessModel.GetLeasedBlockWitnesses()
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, types2.AppErrInternal
+	}
+	for _, witness := range witnesses {
+		resp.Jobs = append(resp.Jobs, &types.ProverJob{
+			Height:         witness.Height,
+			WorkerId:       witness.WorkerId,
+			LeasedAt:       witness.LeasedAt.UnixMilli(),
+			LeaseExpiredAt: witness.LeaseExpiredAt.UnixMilli(),
+			Duration:       now.Sub(witness.LeasedAt).Milliseconds(),
+			Expired:        witness.LeaseExpiredAt.Before(now),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,73 @@
+package royalty
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/dao/royalty"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	FormatJson = "json"
+	FormatCsv  = "csv"
+)
+
+// parseTimeRange returns the range [from, to), to is now when it is not set.
+func parseTimeRange(from, to int64) (time.Time, time.Time, error) {
+	if to == 0 {
+		to = time.Now().Unix() + 1
+	}
+	if from < 0 || to <= from {
+		return time.Time{}, time.Time{}, types2.AppErrInvalidParam.RefineError("invalid time range")
+	}
+	return time.Unix(from, 0), time.Unix(to, 0), nil
+}
+
+func convertEarnings(svcCtx *svc.ServiceContext, from, to time.Time, earnings []*royalty.Earning) *types.Earnings {
+	resp := &types.Earnings{
+		From:     from.Unix(),
+		To:       to.Unix(),
+		Earnings: make([]*types.Earning, 0, len(earnings)),
+	}
+	for _, earning := range earnings {
+		assetName, _ := svcCtx.MemCache.GetAssetNameById(earning.AssetId)
+		resp.Earnings = append(resp.Earnings, &types.Earning{
+			CollectionId: earning.CollectionId,
+			NftIndex:     earning.NftIndex,
+			AssetId:      earning.AssetId,
+			AssetName:    assetName,
+			Count:        earning.Count,
+			Amount:       earning.Amount,
+		})
+	}
+	return resp
+}
+
+func WriteEarningsCsv(w http.ResponseWriter, earnings *types.Earnings) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=earnings.csv")
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	records := [][]string{{"collection_id", "nft_index", "asset_id", "asset_name", "count", "amount"}}
+	for _, earning := range earnings.Earnings {
+		records = append(records, []string{
+			strconv.FormatInt(earning.CollectionId, 10),
+			strconv.FormatInt(earning.NftIndex, 10),
+			strconv.FormatInt(earning.AssetId, 10),
+			earning.AssetName,
+			strconv.FormatInt(earning.Count, 10),
+			earning.Amount,
+		})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		logx.Errorf("fail to write earnings csv, err: %s", err.Error())
+	}
+}
@@ -0,0 +1,38 @@
+package royalty
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+type GetCreatorEarningsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetCreatorEarningsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCreatorEarningsLogic {
+	return &GetCreatorEarningsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetCreatorEarningsLogic) GetCreatorEarnings(req *types.ReqGetCreatorEarnings) (resp *types.Earnings, err error) {
+	from, to, err := parseTimeRange(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	earnings, err := l.svcCtx.RoyaltyModel.GetCreatorEarnings(int64(req.AccountIndex), from, to)
+	if err != nil && err != types2.DbErrNotFound {
+		return nil, types2.AppErrInternal
+	}
+	return convertEarnings(l.svcCtx, from, to, earnings), nil
+}
@@ -0,0 +1,28 @@
+package transaction
+
+import (
+	"strconv"
+
+	"github.com/zeromicro/go-zero/core/metric"
+
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+const metricNamespace = "zkbnb"
+
+var sendTxRejectionsMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+	Namespace: metricNamespace,
+	Subsystem: "apiserver",
+	Name:      "sendtx_rejections_total",
+	Help:      "Number of txs rejected by the send tx api by the error code.",
+	Labels:    []string{"tx_type", "code"},
+})
+
+// recordSendTxRejection counts the rejected tx, errors without an app error code are counted as unknown.
+func recordSendTxRejection(txType uint32, err error) {
+	code := "unknown"
+	if appErr, ok := err.(types2.Error); ok {
+		code = strconv.FormatInt(int64(appErr.Code()), 10)
+	}
+	sendTxRejectionsMetric.Inc(strconv.FormatInt(int64(txType), 10), code)
+}
@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/zeromicro/go-zero/core/logx"
 
@@ -30,6 +31,18 @@ func NewSendTxLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SendTxLogi
 
 func (s *SendTxLogic) SendTx(req *types.ReqSendTx) (resp *types.TxHash, err error) {
 	resp = &types.TxHash{}
+	defer func() {
+		if err != nil {
+			recordSendTxRejection(req.TxType, err)
+		}
+	}()
+	paused, err := s.isTxTypePaused(req.TxType)
+	if err != nil {
+		return resp, types2.AppErrInternal
+	}
+	if paused {
+		return resp, types2.AppErrTxTypePaused
+	}
 	executor, err := s.getExecutor(int(req.TxType), req.TxInfo)
 	if err != nil {
 		return resp, types2.AppErrInvalidTx
@@ -66,9 +79,34 @@ func (s *SendTxLogic) SendTx(req *types.ReqSendTx) (resp *types.TxHash, err erro
 	return resp, nil
 }
 
+// isTxTypePaused checks whether the tx type is paused by the admin api.
+func (s *SendTxLogic) isTxTypePaused(txType uint32) (bool, error) {
+	pausedTxTypes, err := s.svcCtx.MemCache.GetSysConfigWithFallback(types2.PausedTxTypes, func() (interface{}, error) {
+		return s.svcCtx.SysConfigModel.GetSysConfigByName(types2.PausedTxTypes)
+	})
+	if err == types2.DbErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var txTypes []uint32
+	err = json.Unmarshal([]byte(pausedTxTypes.Value), &txTypes)
+	if err != nil {
+		logx.Errorf("fail to unmarshal paused tx types: %s, err: %s", pausedTxTypes.Value, err.Error())
+		return false, err
+	}
+	for _, pausedTxType := range txTypes {
+		if pausedTxType == txType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (s *SendTxLogic) getExecutor(txType int, txInfo string) (executor.TxExecutor, error) {
 	bc := core.NewBlockChainForDryRun(s.svcCtx.AccountModel, s.svcCtx.LiquidityModel, s.svcCtx.NftModel, s.svcCtx.MempoolModel,
-		s.svcCtx.RedisCache)
+		s.svcCtx.AssetModel, s.svcCtx.RedisCache)
 	t := &tx.Tx{TxType: int64(txType), TxInfo: txInfo}
 
 	switch txType {
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/config"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+const ApiKeyHeader = "X-Api-Key"
+
+type operatorKey struct{}
+
+type AdminAuthMiddleware struct {
+	apiKeys []config.AdminApiKey
+}
+
+func NewAdminAuthMiddleware(apiKeys []config.AdminApiKey) *AdminAuthMiddleware {
+	return &AdminAuthMiddleware{apiKeys: apiKeys}
+}
+
+// Handle authorizes the request by the api key, the name of the key is put in the context as the operator.
+func (m *AdminAuthMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		operator, ok := m.authorize(r.Header.Get(ApiKeyHeader))
+		if !ok {
+			logx.Errorf("unauthorized admin request from %s: %s", r.RemoteAddr, r.URL.Path)
+			http.Error(w, types2.AppErrUnauthorized.Error(), http.StatusUnauthorized)
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), operatorKey{}, operator)))
+	}
+}
+
+func (m *AdminAuthMiddleware) authorize(apiKey string) (string, bool) {
+	if apiKey == "" {
+		return "", false
+	}
+	hash := sha256.Sum256([]byte(apiKey))
+	keyHash := []byte(hex.EncodeToString(hash[:]))
+	for _, key := range m.apiKeys {
+		if subtle.ConstantTimeCompare(keyHash, []byte(strings.ToLower(key.KeyHash))) == 1 {
+			return key.Name, true
+		}
+	}
+	return "", false
+}
+
+// Operator returns the name of the api key which authorizes the request.
+func Operator(ctx context.Context) string {
+	operator, _ := ctx.Value(operatorKey{}).(string)
+	return operator
+}
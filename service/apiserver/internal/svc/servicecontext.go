@@ -4,22 +4,34 @@ import (
 	"time"
 
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"github.com/bnb-chain/zkbnb/common/storage"
 	"github.com/bnb-chain/zkbnb/dao/account"
 	"github.com/bnb-chain/zkbnb/dao/asset"
+	"github.com/bnb-chain/zkbnb/dao/auditlog"
 	"github.com/bnb-chain/zkbnb/dao/block"
+	"github.com/bnb-chain/zkbnb/dao/blockwitness"
 	"github.com/bnb-chain/zkbnb/dao/dbcache"
+	"github.com/bnb-chain/zkbnb/dao/l1rolluptx"
+	"github.com/bnb-chain/zkbnb/dao/l1syncedblock"
 	"github.com/bnb-chain/zkbnb/dao/liquidity"
 	"github.com/bnb-chain/zkbnb/dao/mempool"
 	"github.com/bnb-chain/zkbnb/dao/nft"
+	"github.com/bnb-chain/zkbnb/dao/offer"
+	"github.com/bnb-chain/zkbnb/dao/proof"
+	"github.com/bnb-chain/zkbnb/dao/proverworker"
+	"github.com/bnb-chain/zkbnb/dao/royalty"
 	"github.com/bnb-chain/zkbnb/dao/sysconfig"
 	"github.com/bnb-chain/zkbnb/dao/tx"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/cache"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/config"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/fetcher/metadata"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/fetcher/price"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/fetcher/state"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/middleware"
 )
 
 type ServiceContext struct {
@@ -36,18 +48,40 @@ type ServiceContext struct {
 	LiquidityHistoryModel liquidity.LiquidityHistoryModel
 	BlockModel            block.BlockModel
 	NftModel              nft.L2NftModel
+	NftHistoryModel       nft.L2NftHistoryModel
+	OfferModel            offer.OfferModel
+	RoyaltyModel          royalty.RoyaltyModel
 	AssetModel            asset.AssetModel
 	SysConfigModel        sysconfig.SysConfigModel
+	BlockWitnessModel     blockwitness.BlockWitnessModel
+	ProverWorkerModel     proverworker.ProverWorkerModel
+	L1SyncedBlockModel    l1syncedblock.L1SyncedBlockModel
+	ProofModel            proof.ProofModel
+	L1RollupTxModel       l1rolluptx.L1RollupTxModel
+	AuditLogModel         auditlog.AuditLogModel
 
-	PriceFetcher price.Fetcher
-	StateFetcher state.Fetcher
+	PriceFetcher    price.Fetcher
+	StateFetcher    state.Fetcher
+	MetadataFetcher metadata.Fetcher
+
+	AdminAuth rest.Middleware
 }
 
+const defaultNftMetadataPath = "./data/nft-metadata"
+
 func NewServiceContext(c config.Config) *ServiceContext {
 	gormPointer, err := gorm.Open(postgres.Open(c.Postgres.DataSource))
 	if err != nil {
 		logx.Must(err)
 	}
+	metadataStorageConfig := c.NftMetadata.Storage
+	if metadataStorageConfig.Type == "" {
+		metadataStorageConfig = storage.Config{Type: storage.LocalStorageType, LocalPath: defaultNftMetadataPath}
+	}
+	metadataStorage, err := storage.NewStorage(metadataStorageConfig)
+	if err != nil {
+		logx.Must(err)
+	}
 	redisCache := dbcache.NewRedisCache(c.CacheRedis[0].Host, c.CacheRedis[0].Pass, 15*time.Minute)
 
 	mempoolModel := mempool.NewMempoolModel(gormPointer)
@@ -70,10 +104,22 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		LiquidityHistoryModel: liquidity.NewLiquidityHistoryModel(gormPointer),
 		BlockModel:            block.NewBlockModel(gormPointer),
 		NftModel:              nftModel,
+		NftHistoryModel:       nft.NewL2NftHistoryModel(gormPointer),
+		OfferModel:            offer.NewOfferModel(gormPointer),
+		RoyaltyModel:          royalty.NewRoyaltyModel(gormPointer),
 		AssetModel:            assetModel,
 		SysConfigModel:        sysconfig.NewSysConfigModel(gormPointer),
+		BlockWitnessModel:     blockwitness.NewBlockWitnessModel(gormPointer),
+		ProverWorkerModel:     proverworker.NewProverWorkerModel(gormPointer),
+		L1SyncedBlockModel:    l1syncedblock.NewL1SyncedBlockModel(gormPointer),
+		ProofModel:            proof.NewProofModel(gormPointer),
+		L1RollupTxModel:       l1rolluptx.NewL1RollupTxModel(gormPointer),
+		AuditLogModel:         auditlog.NewAuditLogModel(gormPointer),
+
+		PriceFetcher:    price.NewFetcher(memCache, c.CoinMarketCap.Url, c.CoinMarketCap.Token),
+		StateFetcher:    state.NewFetcher(redisCache, accountModel, liquidityModel, nftModel),
+		MetadataFetcher: metadata.NewFetcher(memCache, metadataStorage),
 
-		PriceFetcher: price.NewFetcher(memCache, c.CoinMarketCap.Url, c.CoinMarketCap.Token),
-		StateFetcher: state.NewFetcher(redisCache, accountModel, liquidityModel, nftModel),
+		AdminAuth: middleware.NewAdminAuthMiddleware(c.Admin.ApiKeys).Handle,
 	}
 }
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/middleware"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	testAdminApiKey = "test-api-key"
+	// sha256 of testAdminApiKey
+	testAdminApiKeyHash = "4c806362b613f7496abf284146efd31da90e4b16169fe001841ca17290f427c4"
+)
+
+func (s *ApiServerSuite) TestGetAdminStatus() {
+	tests := []struct {
+		name     string
+		apiKey   string
+		httpCode int
+	}{
+		{"authorized", testAdminApiKey, 200},
+		{"invalid api key", "invalid", 401},
+		{"no api key", "", 401},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetAdminStatus(s, tt.apiKey)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				assert.NotNil(t, result.PausedTxTypes)
+				assert.True(t, result.CurrentHeight >= result.VerifiedHeight)
+				fmt.Printf("result: %+v \n", result)
+			}
+		})
+	}
+
+}
+
+func (s *ApiServerSuite) TestPauseSendTx() {
+	httpCode, result := UpdateSendTx(s, "pauseSendTx", []uint32{types2.TxTypeTransfer})
+	assert.Equal(s.T(), http.StatusOK, httpCode)
+	assert.Contains(s.T(), result.PausedTxTypes, uint32(types2.TxTypeTransfer))
+
+	httpCode, _ = UpdateSendTx(s, "pauseSendTx", []uint32{types2.TxTypeDeposit})
+	assert.Equal(s.T(), http.StatusBadRequest, httpCode)
+
+	httpCode, result = UpdateSendTx(s, "resumeSendTx", []uint32{types2.TxTypeTransfer})
+	assert.Equal(s.T(), http.StatusOK, httpCode)
+	assert.NotContains(s.T(), result.PausedTxTypes, uint32(types2.TxTypeTransfer))
+}
+
+func GetAdminStatus(s *ApiServerSuite, apiKey string) (int, *types.AdminStatus) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/admin/status", s.url), nil)
+	assert.NoError(s.T(), err)
+	if apiKey != "" {
+		req.Header.Set(middleware.ApiKeyHeader, apiKey)
+	}
+	return doAdminRequest(s, req)
+}
+
+func UpdateSendTx(s *ApiServerSuite, action string, txTypes []uint32) (int, *types.AdminStatus) {
+	body, err := json.Marshal(&types.ReqUpdateSendTx{TxTypes: txTypes})
+	assert.NoError(s.T(), err)
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/admin/%s", s.url, action), bytes.NewReader(body))
+	assert.NoError(s.T(), err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.ApiKeyHeader, testAdminApiKey)
+	return doAdminRequest(s, req)
+}
+
+func doAdminRequest(s *ApiServerSuite, req *http.Request) (int, *types.AdminStatus) {
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.AdminStatus{}
+	//nolint: errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
@@ -36,6 +36,7 @@ func (s *ApiServerSuite) TestGetAssets() {
 				assert.NotNil(t, result.Assets[0].Symbol)
 				assert.NotNil(t, result.Assets[0].Address)
 				assert.NotNil(t, result.Assets[0].IsGasAsset)
+				assert.NotNil(t, result.Assets[0].Status)
 				fmt.Printf("result: %+v \n", result)
 			}
 		})
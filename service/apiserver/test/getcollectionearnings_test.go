@@ -0,0 +1,67 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func (s *ApiServerSuite) TestGetCollectionEarnings() {
+	type args struct {
+		accountIndex int
+		collectionId int
+		from         int64
+		to           int64
+	}
+
+	type testcase struct {
+		name     string
+		args     args
+		httpCode int
+	}
+
+	tests := []testcase{
+		{"invalid index", args{-1, 0, 0, 0}, 400},
+		{"invalid collection", args{2, -1, 0, 0}, 400},
+		{"invalid range", args{2, 0, 100, 100}, 400},
+		{"not found", args{99999999, 0, 0, 0}, 200},
+		{"found", args{2, 0, 0, 0}, 200},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetCollectionEarnings(s, tt.args.accountIndex, tt.args.collectionId, tt.args.from, tt.args.to)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				for _, earning := range result.Earnings {
+					assert.Equal(t, int64(tt.args.collectionId), earning.CollectionId)
+				}
+				fmt.Printf("result: %+v \n", result)
+			}
+		})
+	}
+
+}
+
+func GetCollectionEarnings(s *ApiServerSuite, accountIndex, collectionId int, from, to int64) (int, *types.Earnings) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/collectionEarnings?account_index=%d&collection_id=%d&from=%d&to=%d", s.url, accountIndex, collectionId, from, to))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.Earnings{}
+	//nolint:errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
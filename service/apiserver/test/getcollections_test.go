@@ -0,0 +1,68 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func (s *ApiServerSuite) TestGetCollections() {
+	type args struct {
+		by     string
+		value  string
+		offset int
+		limit  int
+	}
+
+	type testcase struct {
+		name     string
+		args     args
+		httpCode int
+	}
+
+	tests := []testcase{
+		{"all", args{"all", "", 0, 10}, 200},
+		{"not found by index", args{"account_index", "9999999999", 0, 10}, 200},
+		{"not found by name", args{"account_name", "notexistname", 0, 10}, 200},
+		{"invalid by", args{"invalidby", "", 0, 10}, 400},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetCollections(s, tt.args.by, tt.args.value, tt.args.offset, tt.args.limit)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				if tt.args.offset < int(result.Total) {
+					assert.True(t, len(result.Collections) > 0)
+					assert.NotNil(t, result.Collections[0].Name)
+					assert.NotNil(t, result.Collections[0].TxHash)
+				}
+				fmt.Printf("result: %+v \n", result)
+			}
+		})
+	}
+
+}
+
+func GetCollections(s *ApiServerSuite, by, value string, offset, limit int) (int, *types.Collections) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/collections?by=%s&value=%s&offset=%d&limit=%d", s.url, by, value, offset, limit))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.Collections{}
+	//nolint:errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
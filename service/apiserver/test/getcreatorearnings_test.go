@@ -0,0 +1,70 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func (s *ApiServerSuite) TestGetCreatorEarnings() {
+	type args struct {
+		accountIndex int
+		from         int64
+		to           int64
+		format       string
+	}
+
+	type testcase struct {
+		name     string
+		args     args
+		httpCode int
+	}
+
+	tests := []testcase{
+		{"invalid index", args{-1, 0, 0, "json"}, 400},
+		{"invalid range", args{2, 100, 10, "json"}, 400},
+		{"invalid format", args{2, 0, 0, "xml"}, 400},
+		{"not found", args{99999999, 0, 0, "json"}, 200},
+		{"found", args{2, 0, 0, "json"}, 200},
+		{"found in csv", args{2, 0, 0, "csv"}, 200},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, body := GetCreatorEarnings(s, tt.args.accountIndex, tt.args.from, tt.args.to, tt.args.format)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				if tt.args.format == "csv" {
+					assert.True(t, strings.HasPrefix(string(body), "collection_id,nft_index,asset_id,asset_name,count,amount"))
+				} else {
+					result := types.Earnings{}
+					assert.NoError(t, json.Unmarshal(body, &result))
+					assert.True(t, result.From < result.To)
+					fmt.Printf("result: %+v \n", result)
+				}
+			}
+		})
+	}
+
+}
+
+func GetCreatorEarnings(s *ApiServerSuite, accountIndex int, from, to int64, format string) (int, []byte) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/creatorEarnings?account_index=%d&from=%d&to=%d&format=%s", s.url, accountIndex, from, to, format))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	return resp.StatusCode, body
+}
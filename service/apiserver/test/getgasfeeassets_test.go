@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/bnb-chain/zkbnb/dao/asset"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
 )
 
@@ -31,6 +32,7 @@ func (s *ApiServerSuite) TestGetGasFeeAssets() {
 				assert.NotNil(t, result.Assets[0].Name)
 				assert.NotNil(t, result.Assets[0].Address)
 				assert.NotNil(t, result.Assets[0].IsGasAsset)
+				assert.Equal(t, asset.StatusActive, result.Assets[0].Status)
 				fmt.Printf("result: %+v \n", result)
 			}
 		})
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func (s *ApiServerSuite) TestGetGovernance() {
+	tests := []struct {
+		name     string
+		httpCode int
+	}{
+		{"found", 200},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetGovernance(s)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				assert.NotNil(t, result.Validators)
+				for _, validator := range result.Validators {
+					assert.NotEmpty(t, validator.Address)
+				}
+				fmt.Printf("result: %+v \n", result)
+			}
+		})
+	}
+
+}
+
+func (s *ApiServerSuite) TestGetGovernanceEvents() {
+	type args struct {
+		offset int
+		limit  int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		httpCode int
+	}{
+		{"found", args{0, 10}, 200},
+		{"invalid limit", args{0, 0}, 400},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetGovernanceEvents(s, tt.args.offset, tt.args.limit)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				assert.NotNil(t, result.Events)
+				assert.True(t, len(result.Events) <= tt.args.limit)
+				for _, event := range result.Events {
+					assert.NotEmpty(t, event.Name)
+					assert.NotEmpty(t, event.L1TxHash)
+				}
+				fmt.Printf("result: %+v \n", result)
+			}
+		})
+	}
+
+}
+
+func GetGovernance(s *ApiServerSuite) (int, *types.Governance) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/governance", s.url))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.Governance{}
+	//nolint: errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
+
+func GetGovernanceEvents(s *ApiServerSuite, offset, limit int) (int, *types.GovernanceEvents) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/governanceEvents?offset=%d&limit=%d", s.url, offset, limit))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.GovernanceEvents{}
+	//nolint: errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
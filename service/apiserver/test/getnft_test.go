@@ -0,0 +1,69 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func (s *ApiServerSuite) TestGetNft() {
+	type testcase struct {
+		name     string
+		args     int64 //nftIndex
+		httpCode int
+	}
+
+	tests := []testcase{
+		{"not found", 1099511627775, 400},
+		{"invalid index", -1, 400},
+	}
+
+	statusCode, accounts := GetAccounts(s, 0, 100)
+	if statusCode == http.StatusOK {
+		for _, account := range accounts.Accounts {
+			statusCode, nfts := GetAccountNfts(s, "account_index", strconv.Itoa(int(account.Index)), 0, 1)
+			if statusCode == http.StatusOK && len(nfts.Nfts) > 0 {
+				tests = append(tests, testcase{"found", nfts.Nfts[0].Index, 200})
+				break
+			}
+		}
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetNft(s, tt.args)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				assert.Equal(t, tt.args, result.Index)
+				assert.NotNil(t, result.ContentHash)
+				assert.NotNil(t, result.OwnerAccountIndex)
+				fmt.Printf("result: %+v \n", result)
+			}
+		})
+	}
+
+}
+
+func GetNft(s *ApiServerSuite, nftIndex int64) (int, *types.Nft) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/nft?nft_index=%d", s.url, nftIndex))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.Nft{}
+	//nolint:errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
@@ -0,0 +1,65 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func (s *ApiServerSuite) TestGetNftHistories() {
+	type args struct {
+		nftIndex int64
+		offset   int
+		limit    int
+	}
+
+	type testcase struct {
+		name     string
+		args     args
+		httpCode int
+	}
+
+	tests := []testcase{
+		{"not found", args{1099511627775, 0, 10}, 200},
+		{"invalid index", args{-1, 0, 10}, 400},
+		{"found", args{0, 0, 10}, 200},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetNftHistories(s, tt.args.nftIndex, tt.args.offset, tt.args.limit)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				if tt.args.offset < int(result.Total) {
+					assert.True(t, len(result.Histories) > 0)
+					assert.Equal(t, tt.args.nftIndex, result.Histories[0].NftIndex)
+				}
+				fmt.Printf("result: %+v \n", result)
+			}
+		})
+	}
+
+}
+
+func GetNftHistories(s *ApiServerSuite, nftIndex int64, offset, limit int) (int, *types.NftHistories) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/nftHistories?nft_index=%d&offset=%d&limit=%d", s.url, nftIndex, offset, limit))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.NftHistories{}
+	//nolint:errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
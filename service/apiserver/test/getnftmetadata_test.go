@@ -0,0 +1,63 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+func (s *ApiServerSuite) TestGetNftMetadata() {
+	type testcase struct {
+		name     string
+		args     string //contentHash
+		httpCode int
+	}
+
+	metadata := `{"name":"zkbnb metadata","image":"ipfs://image"}`
+	contentHash := types2.ComputeNftContentHash([]byte(metadata))
+	tests := []testcase{
+		{"invalid content hash", "invalidhash", 400},
+		{"not found", types2.ComputeNftContentHash([]byte("notexist")), 400},
+	}
+
+	statusCode, _ := UploadNftMetadata(s, contentHash, metadata)
+	if statusCode == http.StatusOK {
+		tests = append(tests, testcase{"found", contentHash, 200})
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetNftMetadata(s, tt.args)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				assert.Equal(t, "zkbnb metadata", result.Name)
+				assert.Equal(t, "ipfs://image", result.Image)
+			}
+		})
+	}
+
+}
+
+func GetNftMetadata(s *ApiServerSuite, contentHash string) (int, *types.NftMetadata) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/nftMetadata?content_hash=%s", s.url, contentHash))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.NftMetadata{}
+	//nolint:errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func (s *ApiServerSuite) TestGetOffers() {
+	type args struct {
+		by     string
+		value  string
+		status string
+		offset int
+		limit  int
+	}
+
+	type testcase struct {
+		name     string
+		args     args
+		httpCode int
+	}
+
+	tests := []testcase{
+		{"invalid by", args{"invalidby", "1", "all", 0, 10}, 400},
+		{"invalid value", args{"nft_index", "-1", "all", 0, 10}, 400},
+		{"invalid status", args{"nft_index", "1", "invalidstatus", 0, 10}, 400},
+		{"invalid limit", args{"collection_id", "1", "pending", 0, 0}, 400},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetOffers(s, tt.args.by, tt.args.value, tt.args.status, tt.args.offset, tt.args.limit)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				if tt.args.offset < int(result.Total) {
+					assert.True(t, len(result.Offers) > 0)
+					assert.NotNil(t, result.Offers[0].Info)
+				}
+				fmt.Printf("result: %+v \n", result)
+			}
+		})
+	}
+
+}
+
+func GetOffers(s *ApiServerSuite, by, value, status string, offset, limit int) (int, *types.Offers) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/offers?by=%s&value=%s&status=%s&offset=%d&limit=%d", s.url, by, value, status, offset, limit))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.Offers{}
+	//nolint:errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
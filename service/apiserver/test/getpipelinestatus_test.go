@@ -0,0 +1,61 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/logic/pipeline"
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func (s *ApiServerSuite) TestGetPipelineStatus() {
+	tests := []struct {
+		name     string
+		httpCode int
+	}{
+		{"found", 200},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetPipelineStatus(s)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				assert.Len(t, result.Stages, 8)
+				assert.Equal(t, pipeline.StageMempool, result.Stages[0].Name)
+				assert.Equal(t, pipeline.StageL1Synced, result.Stages[7].Name)
+				for _, stage := range result.Stages {
+					assert.True(t, stage.Lag >= 0)
+					assert.True(t, stage.Throughput >= 0)
+					if stage.OldestStuckItem != nil {
+						assert.True(t, stage.OldestStuckItem.Duration >= 0)
+					}
+				}
+				fmt.Printf("result: %+v \n", result)
+			}
+		})
+	}
+
+}
+
+func GetPipelineStatus(s *ApiServerSuite) (int, *types.PipelineStatus) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/pipelineStatus", s.url))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.PipelineStatus{}
+	//nolint: errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
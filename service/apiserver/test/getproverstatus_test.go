@@ -0,0 +1,56 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func (s *ApiServerSuite) TestGetProverStatus() {
+	tests := []struct {
+		name     string
+		httpCode int
+	}{
+		{"found", 200},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := GetProverStatus(s)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				assert.NotNil(t, result.Workers)
+				assert.NotNil(t, result.Jobs)
+				for _, job := range result.Jobs {
+					assert.NotEmpty(t, job.WorkerId)
+					assert.True(t, job.Duration >= 0)
+				}
+				fmt.Printf("result: %+v \n", result)
+			}
+		})
+	}
+
+}
+
+func GetProverStatus(s *ApiServerSuite) (int, *types.ProverStatus) {
+	resp, err := http.Get(fmt.Sprintf("%s/api/v1/proverStatus", s.url))
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.ProverStatus{}
+	//nolint: errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
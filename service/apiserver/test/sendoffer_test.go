@@ -0,0 +1,53 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+)
+
+func (s *ApiServerSuite) TestSendOffer() {
+	type testcase struct {
+		name     string
+		args     string //offerInfo
+		httpCode int
+	}
+
+	tests := []testcase{
+		{"invalid offer info", "invalidofferinfo", 400},
+		{"invalid offer type", `{"Type":3,"OfferId":1,"AccountIndex":2,"NftIndex":1,"AssetId":0,"AssetAmount":10000,"ListedAt":1,"ExpiredAt":1,"TreasuryRate":200}`, 400},
+		{"expired", `{"Type":1,"OfferId":1,"AccountIndex":2,"NftIndex":1,"AssetId":0,"AssetAmount":10000,"ListedAt":1,"ExpiredAt":1,"TreasuryRate":200,"Sig":"c2ln"}`, 400},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, _ := SendOffer(s, tt.args)
+			assert.Equal(t, tt.httpCode, httpCode)
+		})
+	}
+
+}
+
+func SendOffer(s *ApiServerSuite, offerInfo string) (int, *types.Offer) {
+	resp, err := http.PostForm(fmt.Sprintf("%s/api/v1/offer", s.url), url.Values{"offer_info": {offerInfo}})
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.Offer{}
+	//nolint:errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/rest"
 
+	"github.com/bnb-chain/zkbnb/common/storage"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/config"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/handler"
 	"github.com/bnb-chain/zkbnb/service/apiserver/internal/svc"
@@ -67,6 +68,8 @@ func (s *ApiServerSuite) SetupSuite() {
 	c.CacheRedis = append(c.CacheRedis, cache.NodeConf{
 		RedisConf: redis.RedisConf{Host: "127.0.0.1"},
 	})
+	c.NftMetadata.Storage = storage.Config{Type: storage.LocalStorageType, LocalPath: s.T().TempDir()}
+	c.Admin.ApiKeys = []config.AdminApiKey{{Name: "test", KeyHash: testAdminApiKeyHash}}
 	logx.DisableStat()
 
 	ctx := svc.NewServiceContext(c)
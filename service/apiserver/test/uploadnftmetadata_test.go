@@ -0,0 +1,68 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/service/apiserver/internal/types"
+	types2 "github.com/bnb-chain/zkbnb/types"
+)
+
+func (s *ApiServerSuite) TestUploadNftMetadata() {
+	type args struct {
+		contentHash string
+		metadata    string
+	}
+
+	type testcase struct {
+		name     string
+		args     args
+		httpCode int
+	}
+
+	metadata := `{"name":"zkbnb","description":"zkbnb nft","image":"ipfs://image","attributes":[{"trait_type":"level","value":5}]}`
+	invalidMetadata := `invalidmetadata`
+	tests := []testcase{
+		{"invalid content hash", args{"0x1234", metadata}, 400},
+		{"mismatched content hash", args{types2.ComputeNftContentHash([]byte("other")), metadata}, 400},
+		{"invalid metadata", args{types2.ComputeNftContentHash([]byte(invalidMetadata)), invalidMetadata}, 400},
+		{"uploaded", args{types2.ComputeNftContentHash([]byte(metadata)), metadata}, 200},
+		{"uploaded with 0x prefix", args{"0x" + types2.ComputeNftContentHash([]byte(metadata)), metadata}, 200},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			httpCode, result := UploadNftMetadata(s, tt.args.contentHash, tt.args.metadata)
+			assert.Equal(t, tt.httpCode, httpCode)
+			if httpCode == http.StatusOK {
+				assert.Equal(t, "zkbnb", result.Name)
+				assert.Equal(t, 1, len(result.Attributes))
+				assert.Equal(t, "5", result.Attributes[0].Value)
+			}
+		})
+	}
+
+}
+
+func UploadNftMetadata(s *ApiServerSuite, contentHash, metadata string) (int, *types.NftMetadata) {
+	resp, err := http.PostForm(fmt.Sprintf("%s/api/v1/nftMetadata", s.url), url.Values{"content_hash": {contentHash}, "metadata": {metadata}})
+	assert.NoError(s.T(), err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(s.T(), err)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	result := types.NftMetadata{}
+	//nolint:errcheck
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, &result
+}
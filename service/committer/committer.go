@@ -3,6 +3,7 @@ package committer
 import (
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/prometheus"
 
 	"github.com/bnb-chain/zkbnb/service/committer/committer"
 )
@@ -10,6 +11,7 @@ import (
 func Run(configFile string) error {
 	var config committer.Config
 	conf.MustLoad(configFile, &config)
+	prometheus.StartAgent(config.Prometheus)
 
 	committer, err := committer.NewCommitter(&config)
 	if err != nil {
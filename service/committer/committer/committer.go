@@ -1,21 +1,28 @@
 package committer
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"gorm.io/gorm"
+	"strconv"
 	"time"
 
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/prometheus"
 
 	"github.com/bnb-chain/zkbnb/core"
 	"github.com/bnb-chain/zkbnb/dao/block"
 	"github.com/bnb-chain/zkbnb/dao/mempool"
 	"github.com/bnb-chain/zkbnb/dao/tx"
+	"github.com/bnb-chain/zkbnb/types"
 )
 
 const (
 	MaxCommitterInterval = 60 * 1
+
+	// The runtime controls in the sys configs are reloaded at the interval.
+	RuntimeConfigInterval = time.Second
 )
 
 type Config struct {
@@ -24,6 +31,7 @@ type Config struct {
 	BlockConfig struct {
 		OptionalBlockSizes []int
 	}
+	Prometheus prometheus.Config `json:",optional"`
 }
 
 type Committer struct {
@@ -34,6 +42,11 @@ type Committer struct {
 	bc *core.BlockChain
 
 	executedMemPoolTxs []*mempool.MempoolTx
+
+	runtimeConfigLoadedAt time.Time
+	// time in milliseconds of the latest seal block request which is handled
+	sealBlockRequest int64
+	sealRequested    bool
 }
 
 func NewCommitter(config *Config) (*Committer, error) {
@@ -55,6 +68,11 @@ func NewCommitter(config *Config) (*Committer, error) {
 
 		executedMemPoolTxs: make([]*mempool.MempoolTx, 0),
 	}
+	// the requests before the start are ignored
+	committer.sealBlockRequest, err = committer.getSealBlockRequest()
+	if err != nil {
+		return nil, fmt.Errorf("get seal block request error: %v", err)
+	}
 	return committer, nil
 }
 
@@ -99,9 +117,13 @@ func (c *Committer) Run() {
 			}
 
 			tx := convertMempoolTxToTx(mempoolTx)
+			txType := strconv.FormatInt(tx.TxType, 10)
+			startedAt := time.Now()
 			err = c.bc.ApplyTransaction(tx)
+			txExecDurationMetric.Observe(time.Since(startedAt).Microseconds(), txType)
 			if err != nil {
 				logx.Errorf("apply mempool tx ID: %d failed, err %v ", mempoolTx.ID, err)
+				txExecFailuresMetric.Inc(txType)
 				mempoolTx.Status = mempool.FailTxStatus
 				pendingDeleteMempoolTxs = append(pendingDeleteMempoolTxs, mempoolTx)
 				continue
@@ -178,6 +200,15 @@ func (c *Committer) createNewBlock(curBlock *block.Block) error {
 }
 
 func (c *Committer) shouldCommit(curBlock *block.Block) bool {
+	c.loadRuntimeConfig()
+	if c.sealRequested {
+		if len(c.bc.Statedb.Txs) > 0 {
+			return true
+		}
+		// there is nothing to seal
+		c.sealRequested = false
+	}
+
 	var now = time.Now()
 	if (len(c.bc.Statedb.Txs) > 0 && now.Unix()-curBlock.CreatedAt.Unix() >= MaxCommitterInterval) ||
 		len(c.bc.Statedb.Txs) >= c.maxTxsPerBlock {
@@ -192,7 +223,9 @@ func (c *Committer) commitNewBlock(curBlock *block.Block) (*block.Block, error)
 		tx.Status = mempool.SuccessTxStatus
 	}
 
+	startedAt := time.Now()
 	blockSize := c.computeCurrentBlockSize()
+	txsCount := len(c.bc.Statedb.Txs)
 	blockStates, err := c.bc.CommitNewBlock(blockSize, curBlock.CreatedAt.UnixMilli())
 	if err != nil {
 		return nil, err
@@ -280,6 +313,20 @@ func (c *Committer) commitNewBlock(curBlock *block.Block) (*block.Block, error)
 				return err
 			}
 		}
+		// update offer
+		if len(blockStates.PendingUpdateOffer) != 0 {
+			err = c.bc.DB().OfferModel.UpdateOffersStatusInTransact(tx, blockStates.PendingUpdateOffer)
+			if err != nil {
+				return err
+			}
+		}
+		// create new royalty
+		if len(blockStates.PendingNewRoyalty) != 0 {
+			err = c.bc.DB().RoyaltyModel.CreateRoyaltiesInTransact(tx, blockStates.PendingNewRoyalty)
+			if err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -287,18 +334,103 @@ func (c *Committer) commitNewBlock(curBlock *block.Block) (*block.Block, error)
 	}
 
 	c.executedMemPoolTxs = make([]*mempool.MempoolTx, 0)
+	c.sealRequested = false
+
+	blockHeightMetric.Set(float64(blockStates.Block.BlockHeight))
+	blockTxsMetric.Observe(int64(txsCount))
+	blockCommitDurationMetric.Observe(time.Since(startedAt).Milliseconds())
 	return blockStates.Block, nil
 }
 
+// loadRuntimeConfig reloads the block sizes and the seal block request which are changed by the admin api.
+func (c *Committer) loadRuntimeConfig() {
+	if time.Since(c.runtimeConfigLoadedAt) < RuntimeConfigInterval {
+		return
+	}
+	c.runtimeConfigLoadedAt = time.Now()
+
+	blockSizes := c.config.BlockConfig.OptionalBlockSizes
+	sysConfig, err := c.bc.SysConfigModel.GetSysConfigByName(types.OptionalBlockSizes)
+	if err != nil && err != types.DbErrNotFound {
+		logx.Errorf("get block sizes failed: %v", err)
+		return
+	}
+	if sysConfig != nil {
+		runtimeBlockSizes, err := parseBlockSizes(sysConfig.Value, c.config.BlockConfig.OptionalBlockSizes)
+		if err != nil {
+			logx.Errorf("invalid block sizes %s, the configured block sizes are used: %v", sysConfig.Value, err)
+		} else {
+			blockSizes = runtimeBlockSizes
+		}
+	}
+	if fmt.Sprint(blockSizes) != fmt.Sprint(c.optionalBlockSizes) {
+		logx.Infof("block sizes are changed from %v to %v", c.optionalBlockSizes, blockSizes)
+		c.optionalBlockSizes = blockSizes
+		c.maxTxsPerBlock = blockSizes[len(blockSizes)-1]
+	}
+
+	sealBlockRequest, err := c.getSealBlockRequest()
+	if err != nil {
+		logx.Errorf("get seal block request failed: %v", err)
+		return
+	}
+	if sealBlockRequest > c.sealBlockRequest {
+		logx.Infof("sealing the current block as requested at %d", sealBlockRequest)
+		c.sealBlockRequest = sealBlockRequest
+		c.sealRequested = true
+	}
+}
+
+func (c *Committer) getSealBlockRequest() (int64, error) {
+	sysConfig, err := c.bc.SysConfigModel.GetSysConfigByName(types.SealBlockRequest)
+	if err == types.DbErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(sysConfig.Value, 10, 64)
+}
+
+// parseBlockSizes parses the block sizes, which should be a subset of the configured block sizes as
+// the keys of the other block sizes may be missing.
+func parseBlockSizes(value string, configuredBlockSizes []int) ([]int, error) {
+	var blockSizes []int
+	err := json.Unmarshal([]byte(value), &blockSizes)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockSizes) == 0 {
+		return nil, errors.New("no block size")
+	}
+	for i, blockSize := range blockSizes {
+		if i > 0 && blockSize <= blockSizes[i-1] {
+			return nil, errors.New("block sizes are not ascending")
+		}
+		configured := false
+		for _, configuredBlockSize := range configuredBlockSizes {
+			if blockSize == configuredBlockSize {
+				configured = true
+				break
+			}
+		}
+		if !configured {
+			return nil, fmt.Errorf("block size %d is not configured", blockSize)
+		}
+	}
+	return blockSizes, nil
+}
+
 func (c *Committer) computeCurrentBlockSize() int {
-	var blockSize int
-	for i := 0; i < len(c.optionalBlockSizes); i++ {
-		if len(c.bc.Statedb.Txs) <= c.optionalBlockSizes[i] {
-			blockSize = c.optionalBlockSizes[i]
-			break
+	// the configured block sizes are used if the block sizes are reduced after the txs are executed
+	for _, blockSizes := range [][]int{c.optionalBlockSizes, c.config.BlockConfig.OptionalBlockSizes} {
+		for i := 0; i < len(blockSizes); i++ {
+			if len(c.bc.Statedb.Txs) <= blockSizes[i] {
+				return blockSizes[i]
+			}
 		}
 	}
-	return blockSize
+	return 0
 }
 
 func convertMempoolTxToTx(mempoolTx *mempool.MempoolTx) *tx.Tx {
@@ -0,0 +1,24 @@
+package committer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBlockSizes(t *testing.T) {
+	configured := []int{1, 8, 16, 32}
+
+	blockSizes, err := parseBlockSizes("[8,32]", configured)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{8, 32}, blockSizes)
+
+	_, err = parseBlockSizes("[]", configured)
+	assert.Error(t, err)
+	_, err = parseBlockSizes("[32,8]", configured)
+	assert.Error(t, err)
+	_, err = parseBlockSizes("[8,64]", configured)
+	assert.Error(t, err)
+	_, err = parseBlockSizes("8", configured)
+	assert.Error(t, err)
+}
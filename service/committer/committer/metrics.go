@@ -0,0 +1,45 @@
+package committer
+
+import (
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+const metricNamespace = "zkbnb"
+
+var (
+	blockHeightMetric = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "committer",
+		Name:      "height",
+		Help:      "Height of the latest block committed to the database.",
+	})
+	blockTxsMetric = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "committer",
+		Name:      "block_txs",
+		Help:      "Number of txs in each committed block.",
+		Buckets:   []float64{1, 8, 16, 32, 64, 128, 256, 512, 1024},
+	})
+	blockCommitDurationMetric = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "committer",
+		Name:      "block_commit_duration_ms",
+		Help:      "Duration of committing a block to the database in milliseconds.",
+		Buckets:   []float64{10, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+	})
+	txExecDurationMetric = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "committer",
+		Name:      "tx_exec_duration_us",
+		Help:      "Duration of executing a tx in microseconds.",
+		Labels:    []string{"tx_type"},
+		Buckets:   []float64{100, 250, 500, 1000, 2500, 5000, 10000, 50000, 100000, 500000},
+	})
+	txExecFailuresMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "committer",
+		Name:      "tx_exec_failures_total",
+		Help:      "Number of mempool txs which failed to execute.",
+		Labels:    []string{"tx_type"},
+	})
+)
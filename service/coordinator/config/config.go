@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/zeromicro/go-zero/rest"
+
+	"github.com/bnb-chain/zkbnb/common/storage"
+)
+
+type Config struct {
+	rest.RestConf
+	Postgres struct {
+		DataSource string
+	}
+	KeyPath struct {
+		VerifyingKeyPath []string
+		// Manifest of the keys, generated by `zkbnb keys manifest`.
+		ManifestPath string
+	}
+	BlockConfig struct {
+		OptionalBlockSizes []int
+	}
+	// Bearer tokens which are accepted from the prover workers.
+	AuthTokens []string
+	// Lease timeout of the jobs and workers in seconds.
+	LeaseTimeout int64 `json:",optional"`
+	// Blob storage of the witnesses, the witnesses are in Postgres if it is not set.
+	WitnessStorage storage.Config `json:",optional"`
+}
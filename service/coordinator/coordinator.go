@@ -0,0 +1,29 @@
+package coordinator
+
+import (
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/rest"
+
+	"github.com/bnb-chain/zkbnb/service/coordinator/config"
+	"github.com/bnb-chain/zkbnb/service/coordinator/coordinator"
+)
+
+func Run(configFile string) error {
+	var c config.Config
+	conf.MustLoad(configFile, &c)
+
+	co, err := coordinator.NewCoordinator(c)
+	if err != nil {
+		panic(err)
+	}
+
+	server := rest.MustNewServer(c.RestConf)
+	defer server.Stop()
+	coordinator.RegisterHandlers(server, co)
+
+	fmt.Printf("Starting coordinator at %s:%d...\n", c.Host, c.Port)
+	server.Start()
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package coordinator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/zkbnb/common/prove"
+	"github.com/bnb-chain/zkbnb/dao/blockwitness"
+	"github.com/bnb-chain/zkbnb/service/coordinator/config"
+	"github.com/bnb-chain/zkbnb/service/prover/jobqueue"
+)
+
+const DefaultLeaseTimeout = 10 * time.Minute
+
+var ErrInvalidProof = errors.New("invalid proof")
+
+// Coordinator hands out the block witnesses to remote prover workers, the proofs submitted by
+// the workers are verified before they are stored.
+type Coordinator struct {
+	Config config.Config
+
+	JobQueue          jobqueue.JobQueue
+	BlockWitnessModel blockwitness.BlockWitnessModel
+	WitnessStore      *prove.WitnessStore
+
+	// Verifying keys indexed by block size.
+	VerifyingKeys map[int]groth16.VerifyingKey
+}
+
+func NewCoordinator(c config.Config) (*Coordinator, error) {
+	db, err := gorm.Open(postgres.Open(c.Postgres.DataSource))
+	if err != nil {
+		return nil, fmt.Errorf("gorm connect db error, err: %v", err)
+	}
+	if len(c.AuthTokens) == 0 {
+		return nil, fmt.Errorf("auth tokens are not configured")
+	}
+
+	leaseTimeout := time.Duration(c.LeaseTimeout) * time.Second
+	if leaseTimeout <= 0 {
+		leaseTimeout = DefaultLeaseTimeout
+	}
+	witnessStore, err := prove.NewWitnessStore(c.WitnessStorage)
+	if err != nil {
+		return nil, fmt.Errorf("witness storage init error, err: %v", err)
+	}
+	coordinator := &Coordinator{
+		Config:            c,
+		JobQueue:          jobqueue.NewDbJobQueue(db, leaseTimeout, witnessStore),
+		BlockWitnessModel: blockwitness.NewBlockWitnessModel(db),
+		WitnessStore:      witnessStore,
+	}
+	coordinator.VerifyingKeys, err = prove.LoadVerifyingKeys(c.KeyPath.ManifestPath, c.BlockConfig.OptionalBlockSizes, c.KeyPath.VerifyingKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return coordinator, nil
+}
+
+// SubmitProof verifies the proof against the witness of the block before storing it.
+func (c *Coordinator) SubmitProof(workerId string, height int64, formattedProof *prove.FormattedProof) error {
+	if formattedProof == nil {
+		return ErrInvalidProof
+	}
+	witness, err := c.BlockWitnessModel.GetBlockWitnessByHeight(height)
+	if err != nil {
+		return err
+	}
+	cryptoBlock, err := c.WitnessStore.Load(witness)
+	if err != nil {
+		return err
+	}
+	verifyingKey, ok := c.VerifyingKeys[len(cryptoBlock.Txs)]
+	if !ok {
+		return fmt.Errorf("can't find vk for block size %d", len(cryptoBlock.Txs))
+	}
+	blockSize := strconv.Itoa(len(cryptoBlock.Txs))
+	err = prove.VerifyProof(formattedProof, verifyingKey, cryptoBlock.OldStateRoot, cryptoBlock.NewStateRoot, cryptoBlock.BlockCommitment)
+	if err != nil {
+		invalidProofsMetric.Inc(blockSize)
+		return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+	err = c.JobQueue.SubmitProof(workerId, height, formattedProof)
+	if err != nil {
+		return err
+	}
+	submittedProofsMetric.Inc(blockSize)
+	jobDurationMetric.Observe(time.Since(witness.LeasedAt).Milliseconds(), blockSize)
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package coordinator
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"github.com/bnb-chain/zkbnb/service/prover/jobqueue"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+func RegisterHandlers(server *rest.Server, c *Coordinator) {
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{c.authenticate},
+			rest.Route{
+				Method:  http.MethodPost,
+				Path:    jobqueue.RegisterWorkerPath,
+				Handler: c.registerWorkerHandler,
+			},
+			rest.Route{
+				Method:  http.MethodPost,
+				Path:    jobqueue.HeartbeatPath,
+				Handler: c.heartbeatHandler,
+			},
+			rest.Route{
+				Method:  http.MethodPost,
+				Path:    jobqueue.AcquireJobPath,
+				Handler: c.acquireJobHandler,
+			},
+			rest.Route{
+				Method:  http.MethodPost,
+				Path:    jobqueue.RenewJobPath,
+				Handler: c.renewJobHandler,
+			},
+			rest.Route{
+				Method:  http.MethodPost,
+				Path:    jobqueue.ReleaseJobPath,
+				Handler: c.releaseJobHandler,
+			},
+			rest.Route{
+				Method:  http.MethodPost,
+				Path:    jobqueue.SubmitProofPath,
+				Handler: c.submitProofHandler,
+			},
+		),
+	)
+}
+
+func (c *Coordinator) authenticate(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		for _, authToken := range c.Config.AuthTokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(authToken)) == 1 {
+				next(w, r)
+				return
+			}
+		}
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}
+}
+
+func (c *Coordinator) registerWorkerHandler(w http.ResponseWriter, r *http.Request) {
+	var req jobqueue.Worker
+	if err := httpx.ParseJsonBody(r, &req); err != nil || req.WorkerId == "" {
+		http.Error(w, "invalid worker", http.StatusBadRequest)
+		return
+	}
+	writeResult(w, nil, c.JobQueue.RegisterWorker(&req))
+}
+
+func (c *Coordinator) heartbeatHandler(w http.ResponseWriter, r *http.Request) {
+	var req jobqueue.ReqWorker
+	if err := httpx.ParseJsonBody(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeResult(w, nil, c.JobQueue.Heartbeat(req.WorkerId))
+}
+
+func (c *Coordinator) acquireJobHandler(w http.ResponseWriter, r *http.Request) {
+	var req jobqueue.ReqWorker
+	if err := httpx.ParseJsonBody(r, &req); err != nil || req.WorkerId == "" {
+		http.Error(w, "invalid worker", http.StatusBadRequest)
+		return
+	}
+	job, err := c.JobQueue.AcquireJob(req.WorkerId)
+	if err == jobqueue.ErrNoJob {
+		err = nil
+	}
+	writeResult(w, &jobqueue.RespAcquireJob{Job: job}, err)
+}
+
+func (c *Coordinator) renewJobHandler(w http.ResponseWriter, r *http.Request) {
+	var req jobqueue.ReqJob
+	if err := httpx.ParseJsonBody(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeResult(w, nil, c.JobQueue.RenewJob(req.WorkerId, req.Height))
+}
+
+func (c *Coordinator) releaseJobHandler(w http.ResponseWriter, r *http.Request) {
+	var req jobqueue.ReqJob
+	if err := httpx.ParseJsonBody(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeResult(w, nil, c.JobQueue.ReleaseJob(req.WorkerId, req.Height))
+}
+
+func (c *Coordinator) submitProofHandler(w http.ResponseWriter, r *http.Request) {
+	var req jobqueue.ReqSubmitProof
+	if err := httpx.ParseJsonBody(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err := c.SubmitProof(req.WorkerId, req.Height, req.Proof)
+	if err != nil {
+		logx.Errorf("worker %s submits proof of block %d failed, err: %v", req.WorkerId, req.Height, err)
+	}
+	writeResult(w, nil, err)
+}
+
+func writeResult(w http.ResponseWriter, resp interface{}, err error) {
+	switch {
+	case err == nil:
+		if resp == nil {
+			resp = struct{}{}
+		}
+		httpx.OkJson(w, resp)
+	case err == jobqueue.ErrLeaseLost:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err == jobqueue.ErrUnknownWorker:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err == types.DbErrNotFound || errors.Is(err, ErrInvalidProof):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package coordinator
+
+import (
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+const metricNamespace = "zkbnb"
+
+var (
+	jobDurationMetric = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "coordinator",
+		Name:      "job_duration_ms",
+		Help:      "Duration from leasing a block to a worker to receiving its valid proof in milliseconds.",
+		Labels:    []string{"block_size"},
+		Buckets:   []float64{1000, 5000, 10000, 30000, 60000, 120000, 300000, 600000, 1200000},
+	})
+	submittedProofsMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "coordinator",
+		Name:      "proofs_total",
+		Help:      "Number of valid proofs which are submitted by the workers.",
+		Labels:    []string{"block_size"},
+	})
+	invalidProofsMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "coordinator",
+		Name:      "invalid_proofs_total",
+		Help:      "Number of invalid proofs which are rejected.",
+		Labels:    []string{"block_size"},
+	})
+)
@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/prometheus"
 )
 
 type Config struct {
@@ -13,6 +14,21 @@ type Config struct {
 		StartL1BlockHeight      int64
 		ConfirmBlocksCount      uint64
 		MaxHandledBlocksCount   int64
+		// The logs, head height and contract calls are read from two of the comma separated rpc endpoints in the
+		// sysconfig, and the monitor acts on them only if the endpoints agree.
+		QuorumReads bool `json:",optional"`
 	}
-	LogConf logx.LogConf
+	// Watchdog of the priority requests, its report is served at /health and /health/priorityRequests.
+	Watchdog struct {
+		// Address of the health endpoints, e.g. 0.0.0.0:8090, the endpoints are disabled if it is empty.
+		ListenOn string `json:",optional"`
+		// The health is red if the oldest open request on L1 expires within the number of L1 blocks,
+		// and yellow within twice the number, 28800 by default.
+		ExpirationWarningBlocks int64 `json:",optional"`
+		// The health is yellow if any request is not executed in a L2 block for longer than the seconds,
+		// 600 by default.
+		MaxPendingTime int64 `json:",optional"`
+	} `json:",optional"`
+	LogConf    logx.LogConf
+	Prometheus prometheus.Config `json:",optional"`
 }
@@ -1,10 +1,13 @@
 package monitor
 
 import (
+	"fmt"
+
 	"github.com/robfig/cron/v3"
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/proc"
+	"github.com/zeromicro/go-zero/core/prometheus"
 
 	"github.com/bnb-chain/zkbnb/service/monitor/config"
 	"github.com/bnb-chain/zkbnb/service/monitor/monitor"
@@ -19,6 +22,7 @@ func Run(configFile string) error {
 	proc.AddShutdownListener(func() {
 		logx.Close()
 	})
+	prometheus.StartAgent(c.Prometheus)
 	cronjob := cron.New(cron.WithChain(
 		cron.SkipIfStillRunning(cron.DiscardLogger),
 	))
@@ -53,7 +57,71 @@ func Run(configFile string) error {
 	}); err != nil {
 		panic(err)
 	}
+
+	// check priority requests
+	if _, err := cronjob.AddFunc(fmt.Sprintf("@every %ds", monitor.WatchdogInterval), func() {
+		err := m.CheckPriorityRequests()
+		if err != nil {
+			logx.Errorf("priority request watchdog error, %v", err)
+		}
+	}); err != nil {
+		panic(err)
+	}
+	if c.Watchdog.ListenOn != "" {
+		go func() {
+			logx.Infof("serving health endpoints at %s", c.Watchdog.ListenOn)
+			if err := m.ServeHealth(c.Watchdog.ListenOn); err != nil {
+				panic(err)
+			}
+		}()
+	}
+
+	// check l1 rpc endpoints
+	if _, err := cronjob.AddFunc("@every 30s", m.CheckL1Endpoints); err != nil {
+		panic(err)
+	}
 	cronjob.Start()
 	logx.Info("Starting monitor cronjob ...")
 	select {}
 }
+
+// Resync compares the synced l1 blocks from `from` to `to` with the logs on L1 and prints the discrepancies.
+func Resync(configFile string, from, to int64, repair bool) error {
+	if from > to {
+		return fmt.Errorf("invalid range from %d to %d", from, to)
+	}
+	var c config.Config
+	conf.MustLoad(configFile, &c)
+	m := monitor.NewMonitor(c)
+	discrepancies, err := m.Resync(from, to, repair)
+	for _, d := range discrepancies {
+		fmt.Println(d)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d discrepancies are found in l1 blocks from %d to %d\n", len(discrepancies), from, to)
+	return nil
+}
+
+// RefreshAsset updates the metadata of the asset from its ERC20 contract and prints the changes.
+func RefreshAsset(configFile string, assetId int64) error {
+	var c config.Config
+	conf.MustLoad(configFile, &c)
+	m := monitor.NewMonitor(c)
+	storedAsset, refreshedAsset, err := m.RefreshAsset(assetId)
+	if err != nil {
+		return err
+	}
+	if *storedAsset == *refreshedAsset {
+		fmt.Printf("asset %d is up to date\n", assetId)
+		return nil
+	}
+	fmt.Printf("asset %d is refreshed: name %s -> %s, symbol %s -> %s, decimals %d -> %d\n", assetId,
+		storedAsset.AssetName, refreshedAsset.AssetName, storedAsset.AssetSymbol, refreshedAsset.AssetSymbol,
+		storedAsset.Decimals, refreshedAsset.Decimals)
+	if storedAsset.Decimals != refreshedAsset.Decimals {
+		fmt.Println("the decimals are changed, the amounts of the asset are shown in the new decimals")
+	}
+	return nil
+}
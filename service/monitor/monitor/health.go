@@ -0,0 +1,49 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitor
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+const (
+	HealthPath                 = "/health"
+	PriorityRequestsHealthPath = "/health/priorityRequests"
+)
+
+// ServeHealth serves the health endpoints, the status code is 503 if the health is red.
+func (m *Monitor) ServeHealth(listenOn string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(HealthPath, func(w http.ResponseWriter, r *http.Request) {
+		report := m.PriorityRequestHealth()
+		httpx.WriteJson(w, healthStatusCode(report.Status), map[string]string{"status": report.Status})
+	})
+	mux.HandleFunc(PriorityRequestsHealthPath, func(w http.ResponseWriter, r *http.Request) {
+		report := m.PriorityRequestHealth()
+		httpx.WriteJson(w, healthStatusCode(report.Status), report)
+	})
+	return http.ListenAndServe(listenOn, mux)
+}
+
+func healthStatusCode(status string) int {
+	if status == HealthRed {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
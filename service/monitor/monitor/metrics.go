@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitor
+
+import (
+	"strconv"
+
+	"github.com/zeromicro/go-zero/core/metric"
+
+	"github.com/bnb-chain/zkbnb/dao/l1syncedblock"
+)
+
+const metricNamespace = "zkbnb"
+
+var (
+	l1HeightMetric = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "monitor",
+		Name:      "l1_height",
+		Help:      "Height of the latest l1 block.",
+	})
+	syncedHeightMetric = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "monitor",
+		Name:      "synced_height",
+		Help:      "Height of the latest synced l1 block.",
+		Labels:    []string{"block_type"},
+	})
+	l1LagMetric = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "monitor",
+		Name:      "l1_lag_blocks",
+		Help:      "Number of l1 blocks which are not synced yet, including the blocks waiting for the confirmations.",
+		Labels:    []string{"block_type"},
+	})
+	eventsMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "monitor",
+		Name:      "events_total",
+		Help:      "Number of l1 events which are synced.",
+		Labels:    []string{"block_type", "event_type"},
+	})
+	priorityRequestsMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "monitor",
+		Name:      "priority_requests_total",
+		Help:      "Number of priority requests which are moved to the mempool.",
+	})
+)
+
+var eventTypeNames = map[uint8]string{
+	EventTypeNewPriorityRequest:    "NewPriorityRequest",
+	EventTypeCommittedBlock:        "BlockCommit",
+	EventTypeVerifiedBlock:         "BlockVerification",
+	EventTypeRevertedBlock:         "BlocksRevert",
+	EventTypeAddAsset:              "NewAsset",
+	EventTypeNewGovernor:           "NewGovernor",
+	EventTypeNewAssetGovernance:    "NewAssetGovernance",
+	EventTypeValidatorStatusUpdate: "ValidatorStatusUpdate",
+	EventTypeAssetPausedUpdate:     "AssetPausedUpdate",
+}
+
+func syncedBlockTypeName(blockType int) string {
+	if blockType == l1syncedblock.TypeGovernance {
+		return "governance"
+	}
+	return "generic"
+}
+
+func eventTypeName(eventType uint8) string {
+	if name, ok := eventTypeNames[eventType]; ok {
+		return name
+	}
+	return strconv.Itoa(int(eventType))
+}
+
+// recordSyncMetrics records the progress of syncing the type of l1 blocks up to the synced height.
+func recordSyncMetrics(blockType int, l1Height uint64, syncedHeight int64, events []*L1EventInfo) {
+	name := syncedBlockTypeName(blockType)
+	l1HeightMetric.Set(float64(l1Height))
+	syncedHeightMetric.Set(float64(syncedHeight), name)
+	l1LagMetric.Set(float64(int64(l1Height)-syncedHeight), name)
+	for _, event := range events {
+		eventsMetric.Inc(name, eventTypeName(event.EventType))
+	}
+}
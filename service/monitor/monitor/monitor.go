@@ -17,17 +17,21 @@
 package monitor
 
 import (
+	"sync/atomic"
+
 	"github.com/zeromicro/go-zero/core/logx"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
-	"github.com/bnb-chain/zkbnb-eth-rpc/_rpc"
+	"github.com/bnb-chain/zkbnb/common/l1client"
 	"github.com/bnb-chain/zkbnb/dao/asset"
 	"github.com/bnb-chain/zkbnb/dao/block"
+	"github.com/bnb-chain/zkbnb/dao/blockwitness"
 	"github.com/bnb-chain/zkbnb/dao/l1rolluptx"
 	"github.com/bnb-chain/zkbnb/dao/l1syncedblock"
 	"github.com/bnb-chain/zkbnb/dao/mempool"
 	"github.com/bnb-chain/zkbnb/dao/priorityrequest"
+	"github.com/bnb-chain/zkbnb/dao/proof"
 	"github.com/bnb-chain/zkbnb/dao/sysconfig"
 	"github.com/bnb-chain/zkbnb/service/monitor/config"
 	"github.com/bnb-chain/zkbnb/types"
@@ -36,7 +40,7 @@ import (
 type Monitor struct {
 	Config config.Config
 
-	cli *_rpc.ProviderClient
+	cli *l1client.Client
 
 	zkbnbContractAddress      string
 	governanceContractAddress string
@@ -49,6 +53,11 @@ type Monitor struct {
 	L2AssetModel         asset.AssetModel
 	PriorityRequestModel priorityrequest.PriorityRequestModel
 	L1SyncedBlockModel   l1syncedblock.L1SyncedBlockModel
+	ProofModel           proof.ProofModel
+	BlockWitnessModel    blockwitness.BlockWitnessModel
+
+	// Latest report of the priority request watchdog.
+	watchdogReport atomic.Value
 }
 
 func NewMonitor(c config.Config) *Monitor {
@@ -66,6 +75,8 @@ func NewMonitor(c config.Config) *Monitor {
 		L1SyncedBlockModel:   l1syncedblock.NewL1SyncedBlockModel(db),
 		L2AssetModel:         asset.NewAssetModel(db),
 		SysConfigModel:       sysconfig.NewSysConfigModel(db),
+		ProofModel:           proof.NewProofModel(db),
+		BlockWitnessModel:    blockwitness.NewBlockWitnessModel(db),
 	}
 
 	zkbnbAddressConfig, err := monitor.SysConfigModel.GetSysConfigByName(types.ZkBNBContract)
@@ -90,7 +101,7 @@ func NewMonitor(c config.Config) *Monitor {
 	logx.Infof("ChainName: %s, zkbnbContractAddress: %s, networkRpc: %s",
 		c.ChainConfig.NetworkRPCSysConfigName, zkbnbAddressConfig.Value, networkRpc.Value)
 
-	bscRpcCli, err := _rpc.NewClient(networkRpc.Value)
+	bscRpcCli, err := l1client.NewClient(l1client.ParseEndpoints(networkRpc.Value), c.ChainConfig.QuorumReads)
 	if err != nil {
 		panic(err)
 	}
@@ -98,6 +109,12 @@ func NewMonitor(c config.Config) *Monitor {
 	monitor.zkbnbContractAddress = zkbnbAddressConfig.Value
 	monitor.governanceContractAddress = governanceAddressConfig.Value
 	monitor.cli = bscRpcCli
+	monitor.watchdogReport.Store(initialReport())
 
 	return monitor
 }
+
+// CheckL1Endpoints checks the health of the l1 rpc endpoints.
+func (m *Monitor) CheckL1Endpoints() {
+	m.cli.CheckHealth()
+}
@@ -29,9 +29,9 @@ import (
 	"github.com/zeromicro/go-zero/core/logx"
 	"gorm.io/gorm"
 
-	"github.com/bnb-chain/zkbnb-eth-rpc/_rpc"
 	zkbnb "github.com/bnb-chain/zkbnb-eth-rpc/zkbnb/core/legend"
 	common2 "github.com/bnb-chain/zkbnb/common"
+	"github.com/bnb-chain/zkbnb/common/l1client"
 	"github.com/bnb-chain/zkbnb/dao/block"
 	"github.com/bnb-chain/zkbnb/dao/l1syncedblock"
 	"github.com/bnb-chain/zkbnb/dao/mempool"
@@ -61,8 +61,22 @@ func (m *Monitor) MonitorGenericBlocks() (err error) {
 	safeHeight := latestHeight - m.Config.ChainConfig.ConfirmBlocksCount
 	safeHeight = uint64(common2.MinInt64(int64(safeHeight), handledHeight+m.Config.ChainConfig.MaxHandledBlocksCount))
 	if safeHeight <= uint64(handledHeight) {
+		recordSyncMetrics(l1syncedblock.TypeGeneric, latestHeight, handledHeight, nil)
 		return nil
 	}
+	if latestHandledBlock != nil {
+		forkHeight, reorged, err := m.checkL1Reorg(latestHandledBlock)
+		if err != nil {
+			return fmt.Errorf("failed to check l1 reorg, err: %v", err)
+		}
+		if reorged {
+			return m.rollbackGenericBlocks(forkHeight)
+		}
+	}
+	safeHeader, err := m.cli.GetBlockHeaderByNumber(big.NewInt(int64(safeHeight)))
+	if err != nil {
+		return fmt.Errorf("failed to get block header, err: %v", err)
+	}
 
 	logx.Infof("syncing l1 blocks from %d to %d", big.NewInt(handledHeight+1), big.NewInt(int64(safeHeight)))
 
@@ -82,6 +96,9 @@ func (m *Monitor) MonitorGenericBlocks() (err error) {
 		priorityRequestCountCheck = 0
 
 		relatedBlocks = make(map[int64]*block.Block)
+
+		// the lowest committed height on L1 after the blocks are reverted, -1 if no blocks are reverted
+		revertedCommittedHeight int64 = -1
 	)
 	for _, vlog := range logs {
 		l1EventInfo := &L1EventInfo{
@@ -92,6 +109,9 @@ func (m *Monitor) MonitorGenericBlocks() (err error) {
 		if err != nil {
 			return fmt.Errorf("failed to get block header, err: %v", err)
 		}
+		if logBlock.Hash() != vlog.BlockHash {
+			return fmt.Errorf("l1 block %d is reorged while syncing, try it again", vlog.BlockNumber)
+		}
 
 		switch vlog.Topics[0].Hex() {
 		case zkbnbLogNewPriorityRequestSigHash.Hex():
@@ -145,6 +165,23 @@ func (m *Monitor) MonitorGenericBlocks() (err error) {
 			relatedBlocks[blockHeight].BlockStatus = block.StatusVerifiedAndExecuted
 		case zkbnbLogBlocksRevertSigHash.Hex():
 			l1EventInfo.EventType = EventTypeRevertedBlock
+
+			var event zkbnb.ZkBNBBlocksRevert
+			if err := ZkBNBContractAbi.UnpackIntoInterface(&event, EventNameBlocksRevert, vlog.Data); err != nil {
+				return fmt.Errorf("failed to unpack ZkBNBBlocksRevert err: %v", err)
+			}
+
+			committedHeight := int64(event.TotalBlocksCommitted)
+			logx.Severef("blocks after %d are reverted on L1, tx hash: %s", committedHeight, vlog.TxHash.Hex())
+			if revertedCommittedHeight == -1 || committedHeight < revertedCommittedHeight {
+				revertedCommittedHeight = committedHeight
+			}
+			// the blocks committed earlier in the same range are reverted too
+			for blockHeight, relatedBlock := range relatedBlocks {
+				if blockHeight > committedHeight && relatedBlock.BlockStatus == block.StatusCommitted {
+					revertBlock(relatedBlock)
+				}
+			}
 		default:
 		}
 
@@ -160,6 +197,7 @@ func (m *Monitor) MonitorGenericBlocks() (err error) {
 	}
 	l1BlockMonitorInfo := &l1syncedblock.L1SyncedBlock{
 		L1BlockHeight: int64(safeHeight),
+		L1BlockHash:   safeHeader.Hash().Hex(),
 		BlockInfo:     string(eventInfosBytes),
 		Type:          l1syncedblock.TypeGeneric,
 	}
@@ -188,6 +226,13 @@ func (m *Monitor) MonitorGenericBlocks() (err error) {
 		if err != nil {
 			return err
 		}
+		//revert blocks, it has to be done before the blocks are updated as they may be committed again
+		if revertedCommittedHeight != -1 {
+			err = m.revertBlocksInTransact(tx, revertedCommittedHeight)
+			if err != nil {
+				return err
+			}
+		}
 		//update blocks
 		err = m.BlockModel.UpdateBlocksWithoutTxsInTransact(tx, pendingUpdateBlocks)
 		if err != nil {
@@ -200,10 +245,36 @@ func (m *Monitor) MonitorGenericBlocks() (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to store monitor info, err: %v", err)
 	}
+	recordSyncMetrics(l1syncedblock.TypeGeneric, latestHeight, int64(safeHeight), l1EventInfos)
 	logx.Info("create txs count:", len(priorityRequests))
 	return nil
 }
 
+// revertBlocksInTransact resets the blocks above the committed height to pending, and deletes their
+// rollup txs, proofs and witnesses, so that they are witnessed, proved and committed again from the
+// last stored block.
+func (m *Monitor) revertBlocksInTransact(tx *gorm.DB, committedHeight int64) error {
+	err := m.BlockModel.RevertBlocksInTransact(tx, committedHeight)
+	if err != nil {
+		return err
+	}
+	err = m.L1RollupTxModel.RevertL1RollupTxsInTransact(tx, committedHeight)
+	if err != nil {
+		return err
+	}
+	err = m.ProofModel.DeleteProofsInTransact(tx, committedHeight+1)
+	if err != nil {
+		return err
+	}
+	return m.BlockWitnessModel.DeleteBlockWitnessesInTransact(tx, committedHeight+1)
+}
+
+func revertBlock(b *block.Block) {
+	b.BlockStatus = block.StatusPending
+	b.CommittedTxHash = ""
+	b.CommittedAt = 0
+}
+
 func getMempoolTxsToDelete(blocks []*block.Block, mempoolModel mempool.MempoolModel) ([]*mempool.MempoolTx, error) {
 	var toDeleteMempoolTxs []*mempool.MempoolTx
 	for _, pendingUpdateBlock := range blocks {
@@ -222,7 +293,7 @@ func getMempoolTxsToDelete(blocks []*block.Block, mempoolModel mempool.MempoolMo
 	return toDeleteMempoolTxs, nil
 }
 
-func getZkBNBContractLogs(cli *_rpc.ProviderClient, zkbnbContract string, startHeight, endHeight uint64) ([]types.Log, error) {
+func getZkBNBContractLogs(cli *l1client.Client, zkbnbContract string, startHeight, endHeight uint64) ([]types.Log, error) {
 	query := ethereum.FilterQuery{
 		FromBlock: big.NewInt(int64(startHeight)),
 		ToBlock:   big.NewInt(int64(endHeight)),
@@ -235,13 +306,12 @@ func getZkBNBContractLogs(cli *_rpc.ProviderClient, zkbnbContract string, startH
 	return logs, nil
 }
 
-func getPriorityRequestCount(cli *_rpc.ProviderClient, zkbnbContract string, startHeight, endHeight uint64) (int, error) {
-	zkbnbInstance, err := zkbnb.LoadZkBNBInstance(cli, zkbnbContract)
+func getPriorityRequestCount(cli *l1client.Client, zkbnbContract string, startHeight, endHeight uint64) (int, error) {
+	zkbnbFilterer, err := zkbnb.NewZkBNBFilterer(common.HexToAddress(zkbnbContract), cli)
 	if err != nil {
 		return 0, err
 	}
-	priorityRequests, err := zkbnbInstance.ZkBNBFilterer.
-		FilterNewPriorityRequest(&bind.FilterOpts{Start: startHeight, End: &endHeight})
+	priorityRequests, err := zkbnbFilterer.FilterNewPriorityRequest(&bind.FilterOpts{Start: startHeight, End: &endHeight})
 	if err != nil {
 		return 0, err
 	}
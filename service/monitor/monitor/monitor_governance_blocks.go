@@ -38,6 +38,64 @@ import (
 	"github.com/bnb-chain/zkbnb/types"
 )
 
+// newL2Asset gets the asset info by contract address.
+func (m *Monitor) newL2Asset(assetId uint16, assetAddress common.Address) (*asset.Asset, error) {
+	erc20Instance, err := zkbnb.NewErc20Caller(assetAddress, m.cli)
+	if err != nil {
+		return nil, err
+	}
+	name, err := erc20Instance.Name(basic.EmptyCallOpts())
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := erc20Instance.Symbol(basic.EmptyCallOpts())
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := erc20Instance.Decimals(basic.EmptyCallOpts())
+	if err != nil {
+		return nil, err
+	}
+	return &asset.Asset{
+		AssetId:     uint32(assetId),
+		L1Address:   assetAddress.Hex(),
+		AssetName:   name,
+		AssetSymbol: strings.ToUpper(symbol),
+		Decimals:    uint32(decimals),
+		Status:      asset.StatusActive,
+	}, nil
+}
+
+// RefreshAsset updates the name, symbol and decimals of the asset from its ERC20 contract, the status
+// of the asset is kept. It returns the asset before and after the refresh.
+func (m *Monitor) RefreshAsset(assetId int64) (*asset.Asset, *asset.Asset, error) {
+	if assetId == types.BNBAssetId {
+		return nil, nil, fmt.Errorf("asset %d has no erc20 contract", assetId)
+	}
+	storedAsset, err := m.L2AssetModel.GetAssetById(assetId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get asset %d, err: %v", assetId, err)
+	}
+	l2Asset, err := m.newL2Asset(uint16(assetId), common.HexToAddress(storedAsset.L1Address))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get erc20 info of asset %d, err: %v", assetId, err)
+	}
+	refreshedAsset := *storedAsset
+	refreshedAsset.AssetName = l2Asset.AssetName
+	refreshedAsset.AssetSymbol = l2Asset.AssetSymbol
+	refreshedAsset.Decimals = l2Asset.Decimals
+	if refreshedAsset == *storedAsset {
+		return storedAsset, &refreshedAsset, nil
+	}
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		return m.L2AssetModel.UpdateAssetsInTransact(tx, []*asset.Asset{&refreshedAsset})
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update asset %d, err: %v", assetId, err)
+	}
+	return storedAsset, &refreshedAsset, nil
+}
+
 func (m *Monitor) MonitorGovernanceBlocks() (err error) {
 	// get latest handled l1 block from database by chain id
 	latestHandledBlock, err := m.L1SyncedBlockModel.GetLatestL1BlockByType(l1syncedblock.TypeGovernance)
@@ -61,8 +119,22 @@ func (m *Monitor) MonitorGovernanceBlocks() (err error) {
 	safeHeight = uint64(common2.MinInt64(int64(safeHeight), handledHeight+m.Config.ChainConfig.MaxHandledBlocksCount))
 	// check if safe height > handledHeight
 	if safeHeight <= uint64(handledHeight) {
+		recordSyncMetrics(l1syncedblock.TypeGovernance, latestHeight, handledHeight, nil)
 		return nil
 	}
+	if latestHandledBlock != nil {
+		forkHeight, reorged, err := m.checkL1Reorg(latestHandledBlock)
+		if err != nil {
+			return fmt.Errorf("failed to check l1 reorg, err: %v", err)
+		}
+		if reorged {
+			return m.rollbackGovernanceBlocks(forkHeight)
+		}
+	}
+	safeHeader, err := m.cli.GetBlockHeaderByNumber(big.NewInt(int64(safeHeight)))
+	if err != nil {
+		return fmt.Errorf("failed to get block header, err: %v", err)
+	}
 	contractAddress := common.HexToAddress(m.governanceContractAddress)
 	logx.Infof("fromBlock: %d, toBlock: %d", big.NewInt(handledHeight+1), big.NewInt(int64(safeHeight)))
 	query := ethereum.FilterQuery{
@@ -92,31 +164,23 @@ func (m *Monitor) MonitorGovernanceBlocks() (err error) {
 				EventType: EventTypeAddAsset,
 				TxHash:    vlog.TxHash.Hex(),
 			}
-			// get asset info by contract address
-			erc20Instance, err := zkbnb.LoadERC20(m.cli, event.AssetAddress.Hex())
-			if err != nil {
-				return err
+			// the asset exists if the event is synced again after a l1 reorg
+			existingAsset, err := m.L2AssetModel.GetAssetById(int64(event.AssetId))
+			if err != nil && err != types.DbErrNotFound {
+				return fmt.Errorf("unable to get l2 asset by id, err: %v", err)
 			}
-			name, err := erc20Instance.Name(basic.EmptyCallOpts())
-			if err != nil {
-				return err
-			}
-			symbol, err := erc20Instance.Symbol(basic.EmptyCallOpts())
-			if err != nil {
-				return err
+			if err == nil {
+				if existingAsset.L1Address != event.AssetAddress.Hex() {
+					return fmt.Errorf("asset %d is registered with %s, not %s", event.AssetId,
+						existingAsset.L1Address, event.AssetAddress.Hex())
+				}
+				l1EventInfos = append(l1EventInfos, l1EventInfo)
+				continue
 			}
-			decimals, err := erc20Instance.Decimals(basic.EmptyCallOpts())
+			l2AssetInfo, err := m.newL2Asset(event.AssetId, event.AssetAddress)
 			if err != nil {
 				return err
 			}
-			l2AssetInfo := &asset.Asset{
-				AssetId:     uint32(event.AssetId),
-				L1Address:   event.AssetAddress.Hex(),
-				AssetName:   name,
-				AssetSymbol: strings.ToUpper(symbol),
-				Decimals:    uint32(decimals),
-				Status:      asset.StatusActive,
-			}
 			l1EventInfos = append(l1EventInfos, l1EventInfo)
 			l2AssetInfoMap[event.AssetAddress.Hex()] = l2AssetInfo
 		case governanceLogNewGovernorSigHash.Hex():
@@ -170,10 +234,6 @@ func (m *Monitor) MonitorGovernanceBlocks() (err error) {
 				EventType: EventTypeValidatorStatusUpdate,
 				TxHash:    vlog.TxHash.Hex(),
 			}
-			type ValidatorInfo struct {
-				Address  string
-				IsActive bool
-			}
 			// get data from db
 			if pendingNewSysConfigMap[types.Validators] != nil {
 				configInfo := pendingNewSysConfigMap[types.Validators]
@@ -284,6 +344,7 @@ func (m *Monitor) MonitorGovernanceBlocks() (err error) {
 	}
 	syncedBlock := &l1syncedblock.L1SyncedBlock{
 		L1BlockHeight: int64(safeHeight),
+		L1BlockHash:   safeHeader.Hash().Hex(),
 		BlockInfo:     string(eventInfosBytes),
 		Type:          l1syncedblock.TypeGovernance,
 	}
@@ -351,5 +412,6 @@ func (m *Monitor) MonitorGovernanceBlocks() (err error) {
 	if err != nil {
 		return fmt.Errorf("store governance monitor info error, err: %v", err)
 	}
+	recordSyncMetrics(l1syncedblock.TypeGovernance, latestHeight, int64(safeHeight), l1EventInfos)
 	return nil
 }
@@ -201,5 +201,6 @@ func (m *Monitor) MonitorPriorityRequests() error {
 	if err != nil {
 		return fmt.Errorf("unable to create mempool pendingRequests and update priority requests, error: %v", err)
 	}
+	priorityRequestsMetric.Add(float64(len(pendingRequests)))
 	return nil
 }
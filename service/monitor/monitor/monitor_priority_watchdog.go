@@ -0,0 +1,266 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitor
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zeromicro/go-zero/core/logx"
+
+	zkbnb "github.com/bnb-chain/zkbnb-eth-rpc/zkbnb/core/legend"
+	"github.com/bnb-chain/zkbnb/dao/mempool"
+	"github.com/bnb-chain/zkbnb/dao/priorityrequest"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	HealthGreen  = "green"
+	HealthYellow = "yellow"
+	HealthRed    = "red"
+
+	// WatchdogInterval is the interval of the watchdog checks in seconds, the report is stale after
+	// three intervals.
+	WatchdogInterval = 30
+
+	defaultExpirationWarningBlocks = 28800
+	defaultMaxPendingTime          = 600
+)
+
+// PriorityRequestReport compares the priority queue on L1 with the synced requests and the L2 txs.
+type PriorityRequestReport struct {
+	CheckedAt  int64 `json:"checked_at"`
+	L1Height   int64 `json:"l1_height"`
+	DesertMode bool  `json:"desert_mode"`
+	// The requests from L1FirstOpenRequestId to L1NextRequestId are not executed on L1 yet.
+	L1FirstOpenRequestId int64 `json:"l1_first_open_request_id"`
+	L1NextRequestId      int64 `json:"l1_next_request_id"`
+	// The next request to be synced by the monitor, and the next one to be executed in a L2 block.
+	SyncedNextRequestId   int64 `json:"synced_next_request_id"`
+	ExecutedNextRequestId int64 `json:"executed_next_request_id"`
+	// Number of the requests on L1 which are not synced, and the ones which are not executed in a L2 block.
+	SyncGap        int64 `json:"sync_gap"`
+	ExecutionGap   int64 `json:"execution_gap"`
+	FailedRequests int64 `json:"failed_requests"`
+	// Age in seconds and remaining L1 blocks before the expiration of the oldest open request on L1,
+	// they are known only if the request is synced.
+	OldestOpenRequestSynced bool  `json:"oldest_open_request_synced"`
+	OldestOpenRequestAge    int64 `json:"oldest_open_request_age"`
+	BlocksBeforeExpiration  int64 `json:"blocks_before_expiration"`
+	// Age in seconds of the oldest request which is not executed in a L2 block.
+	OldestPendingRequestAge int64 `json:"oldest_pending_request_age"`
+
+	Status  string   `json:"status"`
+	Reasons []string `json:"reasons"`
+}
+
+// CheckPriorityRequests reports the backlog of the priority requests, the alerts are logged when the
+// health is not green.
+func (m *Monitor) CheckPriorityRequests() error {
+	report, err := m.checkPriorityRequests()
+	if err != nil {
+		return fmt.Errorf("failed to check priority requests, err: %v", err)
+	}
+	expirationWarningBlocks := m.Config.Watchdog.ExpirationWarningBlocks
+	if expirationWarningBlocks == 0 {
+		expirationWarningBlocks = defaultExpirationWarningBlocks
+	}
+	maxPendingTime := m.Config.Watchdog.MaxPendingTime
+	if maxPendingTime == 0 {
+		maxPendingTime = defaultMaxPendingTime
+	}
+	report.evaluate(expirationWarningBlocks, maxPendingTime)
+	m.watchdogReport.Store(report)
+
+	switch report.Status {
+	case HealthRed:
+		logx.Severef("priority requests are unhealthy: %s", strings.Join(report.Reasons, "; "))
+	case HealthYellow:
+		logx.Errorf("priority requests are delayed: %s", strings.Join(report.Reasons, "; "))
+	}
+	return nil
+}
+
+func (m *Monitor) checkPriorityRequests() (*PriorityRequestReport, error) {
+	l1Height, err := m.cli.GetHeight()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get l1 height, err: %v", err)
+	}
+	instance, err := zkbnb.NewZkBNBCaller(common.HexToAddress(m.zkbnbContractAddress), m.cli)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(l1Height)}
+	desertMode, err := instance.DesertMode(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get desert mode, err: %v", err)
+	}
+	firstOpenRequestId, err := instance.FirstPriorityRequestId(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get first priority request id, err: %v", err)
+	}
+	openRequests, err := instance.TotalOpenPriorityRequests(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total open priority requests, err: %v", err)
+	}
+	latestRequestId, err := m.PriorityRequestModel.GetLatestRequestId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest request id, err: %v", err)
+	}
+	requests, err := m.PriorityRequestModel.GetPriorityRequestsFromRequestId(int64(firstOpenRequestId))
+	if err != nil && err != types.DbErrNotFound {
+		return nil, fmt.Errorf("failed to get priority requests, err: %v", err)
+	}
+	txHashes := make([]string, 0, len(requests))
+	for _, request := range requests {
+		if request.Status == priorityrequest.HandledStatus {
+			txHashes = append(txHashes, ComputeL1TxTxHash(request.RequestId, request.L1TxHash))
+		}
+	}
+	mempoolTxs, err := m.MempoolModel.GetMempoolTxsByTxHashes(txHashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mempool txs, err: %v", err)
+	}
+	txStatus := make(map[string]int, len(mempoolTxs))
+	for _, mempoolTx := range mempoolTxs {
+		txStatus[mempoolTx.TxHash] = mempoolTx.Status
+	}
+
+	now := time.Now().Unix()
+	report := &PriorityRequestReport{
+		CheckedAt:             now,
+		L1Height:              int64(l1Height),
+		DesertMode:            desertMode,
+		L1FirstOpenRequestId:  int64(firstOpenRequestId),
+		L1NextRequestId:       int64(firstOpenRequestId + openRequests),
+		SyncedNextRequestId:   latestRequestId + 1,
+		ExecutedNextRequestId: int64(firstOpenRequestId),
+	}
+	var oldestPendingRequest *priorityrequest.PriorityRequest
+	for _, request := range requests {
+		executed := false
+		if request.Status == priorityrequest.HandledStatus {
+			// the mempool txs are deleted once their blocks are verified
+			status, ok := txStatus[ComputeL1TxTxHash(request.RequestId, request.L1TxHash)]
+			executed = !ok || status == mempool.ExecutedTxStatus || status == mempool.SuccessTxStatus
+			if ok && status == mempool.FailTxStatus {
+				report.FailedRequests++
+			}
+		}
+		if executed && oldestPendingRequest == nil && request.RequestId == report.ExecutedNextRequestId {
+			report.ExecutedNextRequestId++
+		}
+		if !executed && oldestPendingRequest == nil {
+			oldestPendingRequest = request
+		}
+	}
+	report.SyncGap = report.L1NextRequestId - report.SyncedNextRequestId
+	report.ExecutionGap = report.L1NextRequestId - report.ExecutedNextRequestId
+
+	if len(requests) > 0 && requests[0].RequestId == report.L1FirstOpenRequestId && openRequests > 0 {
+		report.OldestOpenRequestSynced = true
+		report.BlocksBeforeExpiration = requests[0].ExpirationBlock - report.L1Height
+		report.OldestOpenRequestAge, err = m.requestAge(requests[0], now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if oldestPendingRequest != nil {
+		report.OldestPendingRequestAge, err = m.requestAge(oldestPendingRequest, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// requestAge returns the seconds since the l1 block of the request.
+func (m *Monitor) requestAge(request *priorityrequest.PriorityRequest, now int64) (int64, error) {
+	header, err := m.cli.GetBlockHeaderByNumber(big.NewInt(request.L1BlockHeight))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block header, err: %v", err)
+	}
+	return now - int64(header.Time), nil
+}
+
+func (r *PriorityRequestReport) evaluate(expirationWarningBlocks, maxPendingTime int64) {
+	var red, yellow []string
+	if r.DesertMode {
+		red = append(red, "desert mode is activated")
+	}
+	if r.FailedRequests > 0 {
+		red = append(red, fmt.Sprintf("%d requests fail on L2", r.FailedRequests))
+	}
+	if r.L1NextRequestId > r.L1FirstOpenRequestId {
+		switch {
+		case !r.OldestOpenRequestSynced:
+			yellow = append(yellow, fmt.Sprintf("oldest open request %d is not synced", r.L1FirstOpenRequestId))
+		case r.BlocksBeforeExpiration < expirationWarningBlocks:
+			red = append(red, fmt.Sprintf("oldest open request %d expires in %d blocks",
+				r.L1FirstOpenRequestId, r.BlocksBeforeExpiration))
+		case r.BlocksBeforeExpiration < 2*expirationWarningBlocks:
+			yellow = append(yellow, fmt.Sprintf("oldest open request %d expires in %d blocks",
+				r.L1FirstOpenRequestId, r.BlocksBeforeExpiration))
+		}
+	}
+	if r.SyncGap > 0 {
+		yellow = append(yellow, fmt.Sprintf("%d requests are not synced", r.SyncGap))
+	}
+	if r.ExecutionGap > 0 && r.OldestPendingRequestAge > maxPendingTime {
+		yellow = append(yellow, fmt.Sprintf("request %d is not executed for %d seconds",
+			r.ExecutedNextRequestId, r.OldestPendingRequestAge))
+	}
+
+	r.Reasons = append(red, yellow...)
+	switch {
+	case len(red) > 0:
+		r.Status = HealthRed
+	case len(yellow) > 0:
+		r.Status = HealthYellow
+	default:
+		r.Status = HealthGreen
+	}
+}
+
+// PriorityRequestHealth returns the latest report of the watchdog, it is red if the report is stale.
+func (m *Monitor) PriorityRequestHealth() *PriorityRequestReport {
+	return staleReport(m.watchdogReport.Load().(*PriorityRequestReport), time.Now().Unix())
+}
+
+// initialReport is the report before the first check, it becomes stale if the checks keep failing.
+func initialReport() *PriorityRequestReport {
+	return &PriorityRequestReport{
+		CheckedAt: time.Now().Unix(),
+		Status:    HealthYellow,
+		Reasons:   []string{"no report yet"},
+	}
+}
+
+func staleReport(report *PriorityRequestReport, now int64) *PriorityRequestReport {
+	if now-report.CheckedAt <= 3*WatchdogInterval {
+		return report
+	}
+	stale := *report
+	stale.Status = HealthRed
+	stale.Reasons = append([]string{fmt.Sprintf("report is stale for %d seconds", now-report.CheckedAt)},
+		report.Reasons...)
+	return &stale
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityRequestReport(t *testing.T) {
+	healthy := func() *PriorityRequestReport {
+		return &PriorityRequestReport{
+			CheckedAt:               1000,
+			L1FirstOpenRequestId:    10,
+			L1NextRequestId:         12,
+			SyncedNextRequestId:     12,
+			ExecutedNextRequestId:   11,
+			ExecutionGap:            1,
+			OldestOpenRequestSynced: true,
+			BlocksBeforeExpiration:  500,
+			OldestPendingRequestAge: 30,
+		}
+	}
+
+	report := healthy()
+	report.evaluate(100, 60)
+	assert.Equal(t, HealthGreen, report.Status)
+	assert.Empty(t, report.Reasons)
+
+	// No open requests on L1.
+	report = healthy()
+	report.L1NextRequestId, report.BlocksBeforeExpiration = 10, 0
+	report.evaluate(100, 60)
+	assert.Equal(t, HealthGreen, report.Status)
+
+	report = healthy()
+	report.BlocksBeforeExpiration = 150
+	report.evaluate(100, 60)
+	assert.Equal(t, HealthYellow, report.Status)
+
+	report = healthy()
+	report.SyncGap, report.OldestPendingRequestAge = 1, 90
+	report.evaluate(100, 60)
+	assert.Equal(t, HealthYellow, report.Status)
+	assert.Len(t, report.Reasons, 2)
+
+	report = healthy()
+	report.OldestOpenRequestSynced = false
+	report.evaluate(100, 60)
+	assert.Equal(t, HealthYellow, report.Status)
+
+	report = healthy()
+	report.BlocksBeforeExpiration, report.SyncGap = 50, 1
+	report.evaluate(100, 60)
+	assert.Equal(t, HealthRed, report.Status)
+	assert.Equal(t, "oldest open request 10 expires in 50 blocks", report.Reasons[0])
+
+	report = healthy()
+	report.FailedRequests = 1
+	report.evaluate(100, 60)
+	assert.Equal(t, HealthRed, report.Status)
+
+	report = healthy()
+	report.DesertMode = true
+	report.evaluate(100, 60)
+	assert.Equal(t, HealthRed, report.Status)
+
+	// The report becomes red once it is stale.
+	report = healthy()
+	report.evaluate(100, 60)
+	assert.Equal(t, report, staleReport(report, report.CheckedAt+3*WatchdogInterval))
+	stale := staleReport(report, report.CheckedAt+3*WatchdogInterval+1)
+	assert.Equal(t, HealthRed, stale.Status)
+	assert.Equal(t, HealthGreen, report.Status)
+}
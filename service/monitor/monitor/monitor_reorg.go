@@ -0,0 +1,294 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	zkbnb "github.com/bnb-chain/zkbnb-eth-rpc/zkbnb/core/legend"
+	"github.com/bnb-chain/zkbnb/dao/asset"
+	"github.com/bnb-chain/zkbnb/dao/l1syncedblock"
+	"github.com/bnb-chain/zkbnb/dao/priorityrequest"
+	"github.com/bnb-chain/zkbnb/dao/sysconfig"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+// maxReorgSyncedBlocks is the number of the latest synced blocks in which the fork point of a l1 reorg is searched.
+const maxReorgSyncedBlocks = 100
+
+// checkL1Reorg checks the parent hash of the l1 block after the latest synced block. If the latest synced block
+// is orphaned, it returns the height of the latest synced block which is still on the canonical chain.
+func (m *Monitor) checkL1Reorg(latestSyncedBlock *l1syncedblock.L1SyncedBlock) (forkHeight int64, reorged bool, err error) {
+	// the blocks synced before the hashes are stored are trusted
+	if latestSyncedBlock.L1BlockHash == "" {
+		return 0, false, nil
+	}
+	nextHeader, err := m.cli.GetBlockHeaderByNumber(big.NewInt(latestSyncedBlock.L1BlockHeight + 1))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get block header, err: %v", err)
+	}
+	if nextHeader.ParentHash.Hex() == latestSyncedBlock.L1BlockHash {
+		return 0, false, nil
+	}
+
+	syncedBlocks, err := m.L1SyncedBlockModel.GetLatestL1BlocksByType(latestSyncedBlock.Type, maxReorgSyncedBlocks)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get synced blocks, err: %v", err)
+	}
+	for _, syncedBlock := range syncedBlocks {
+		if syncedBlock.L1BlockHash == "" {
+			return syncedBlock.L1BlockHeight, true, nil
+		}
+		header, err := m.cli.GetBlockHeaderByNumber(big.NewInt(syncedBlock.L1BlockHeight))
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to get block header, err: %v", err)
+		}
+		if header.Hash().Hex() == syncedBlock.L1BlockHash {
+			return syncedBlock.L1BlockHeight, true, nil
+		}
+	}
+	if len(syncedBlocks) < maxReorgSyncedBlocks {
+		return m.Config.ChainConfig.StartL1BlockHeight, true, nil
+	}
+	return 0, false, fmt.Errorf("no fork point in the latest %d synced blocks", maxReorgSyncedBlocks)
+}
+
+// rollbackGenericBlocks deletes the synced events and priority requests above the fork height, together with the
+// mempool txs of the handled requests, and resets the blocks committed or verified by the orphaned l1 blocks. It fails
+// if any of the txs is executed, as the l2 blocks can't be rolled back.
+func (m *Monitor) rollbackGenericBlocks(forkHeight int64) error {
+	requests, err := m.PriorityRequestModel.GetPriorityRequestsAboveL1Height(forkHeight)
+	if err != nil && err != types.DbErrNotFound {
+		return fmt.Errorf("failed to get priority requests, err: %v", err)
+	}
+	var txHashes []string
+	for _, request := range requests {
+		if request.Status == priorityrequest.HandledStatus {
+			txHashes = append(txHashes, ComputeL1TxTxHash(request.RequestId, request.L1TxHash))
+		}
+	}
+	orphanedBlocks, err := m.L1SyncedBlockModel.GetL1SyncedBlocksAbove(l1syncedblock.TypeGeneric, forkHeight)
+	if err != nil && err != types.DbErrNotFound {
+		return fmt.Errorf("failed to get synced blocks, err: %v", err)
+	}
+	committedTxHashes, verifiedTxHashes, err := orphanedBlockTxHashes(orphanedBlocks)
+	if err != nil {
+		return fmt.Errorf("failed to parse synced blocks, err: %v", err)
+	}
+	logx.Severef("l1 reorg detected, roll back the generic blocks above %d, priority requests: %d, mempool txs: %d, "+
+		"commit txs: %d, verify txs: %d", forkHeight, len(requests), len(txHashes), len(committedTxHashes), len(verifiedTxHashes))
+
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		err := m.L1SyncedBlockModel.DeleteL1SyncedBlocksInTransact(tx, l1syncedblock.TypeGeneric, forkHeight)
+		if err != nil {
+			return err
+		}
+		err = m.BlockModel.RevertBlocksByL1TxHashesInTransact(tx, committedTxHashes, verifiedTxHashes)
+		if err != nil {
+			return err
+		}
+		err = m.PriorityRequestModel.DeletePriorityRequestsInTransact(tx, requests)
+		if err != nil {
+			return err
+		}
+		err = m.MempoolModel.DeletePendingMempoolTxsByHashesInTransact(tx, txHashes)
+		if err == types.DbErrFailToDeleteMempoolTx {
+			return fmt.Errorf("the priority requests of the orphaned l1 blocks are executed, err: %v", err)
+		}
+		return err
+	})
+	if err != nil {
+		logx.Severef("failed to roll back the generic blocks above %d, err: %v", forkHeight, err)
+		return fmt.Errorf("failed to roll back generic blocks, err: %v", err)
+	}
+	return nil
+}
+
+// orphanedBlockTxHashes returns the l1 txs which committed or verified blocks in the orphaned synced blocks. The
+// blocks reverted by the orphaned l1 blocks can't be restored, they are committed and proved again.
+func orphanedBlockTxHashes(syncedBlocks []*l1syncedblock.L1SyncedBlock) (committedTxHashes, verifiedTxHashes []string, err error) {
+	for _, syncedBlock := range syncedBlocks {
+		if syncedBlock.BlockInfo == "" {
+			continue
+		}
+		var events []*L1EventInfo
+		err = json.Unmarshal([]byte(syncedBlock.BlockInfo), &events)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, event := range events {
+			switch event.EventType {
+			case EventTypeCommittedBlock:
+				committedTxHashes = append(committedTxHashes, event.TxHash)
+			case EventTypeVerifiedBlock:
+				verifiedTxHashes = append(verifiedTxHashes, event.TxHash)
+			case EventTypeRevertedBlock:
+				logx.Severef("blocks reverted by %s in the orphaned l1 block %d are not restored",
+					event.TxHash, syncedBlock.L1BlockHeight)
+			}
+		}
+	}
+	return committedTxHashes, verifiedTxHashes, nil
+}
+
+// rollbackGovernanceBlocks deletes the synced events above the fork height, and re-derives the assets and configs
+// from the governance contract at the fork height. They are updated again when the events are synced again.
+func (m *Monitor) rollbackGovernanceBlocks(forkHeight int64) error {
+	logx.Severef("l1 reorg detected, roll back the governance blocks above %d", forkHeight)
+	instance, err := zkbnb.NewGovernanceCaller(common.HexToAddress(m.governanceContractAddress), m.cli)
+	if err != nil {
+		return fmt.Errorf("failed to load governance contract, err: %v", err)
+	}
+	assetsCount, err := m.L2AssetModel.GetAssetsTotalCount()
+	if err != nil {
+		return fmt.Errorf("failed to get assets count, err: %v", err)
+	}
+	assets, err := m.L2AssetModel.GetAssets(assetsCount, 0)
+	if err != nil && err != types.DbErrNotFound {
+		return fmt.Errorf("failed to get assets, err: %v", err)
+	}
+	configs, err := m.SysConfigModel.GetSysConfigs()
+	if err != nil && err != types.DbErrNotFound {
+		return fmt.Errorf("failed to get sys configs, err: %v", err)
+	}
+	deletedAssets, updatedAssets, updatedConfigs, err := rederiveGovernanceState(instance, forkHeight, assets, configs)
+	if err != nil {
+		return fmt.Errorf("failed to get governance state at %d, err: %v", forkHeight, err)
+	}
+	logx.Severef("roll back the governance state to %d, deleted assets: %d, updated assets: %d, updated configs: %d",
+		forkHeight, len(deletedAssets), len(updatedAssets), len(updatedConfigs))
+
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		err := m.L1SyncedBlockModel.DeleteL1SyncedBlocksInTransact(tx, l1syncedblock.TypeGovernance, forkHeight)
+		if err != nil {
+			return err
+		}
+		if len(deletedAssets) > 0 {
+			err = m.L2AssetModel.DeleteAssetsInTransact(tx, deletedAssets)
+			if err != nil {
+				return err
+			}
+		}
+		if len(updatedAssets) > 0 {
+			err = m.L2AssetModel.UpdateAssetsInTransact(tx, updatedAssets)
+			if err != nil {
+				return err
+			}
+		}
+		if len(updatedConfigs) > 0 {
+			return m.SysConfigModel.UpdateSysConfigsInTransact(tx, updatedConfigs)
+		}
+		return nil
+	})
+	if err != nil {
+		logx.Severef("failed to roll back the governance blocks above %d, err: %v", forkHeight, err)
+		return fmt.Errorf("failed to roll back governance blocks, err: %v", err)
+	}
+	return nil
+}
+
+// governanceCaller reads the state of the governance contract which is synced by the monitor.
+type governanceCaller interface {
+	AssetsList(opts *bind.CallOpts, assetAddress common.Address) (uint16, error)
+	PausedAssets(opts *bind.CallOpts, assetId uint16) (bool, error)
+	NetworkGovernor(opts *bind.CallOpts) (common.Address, error)
+	AssetGovernance(opts *bind.CallOpts) (common.Address, error)
+	Validators(opts *bind.CallOpts, validator common.Address) (bool, error)
+}
+
+// rederiveGovernanceState compares the synced assets and configs with the governance contract at the fork height. It
+// returns the assets which are registered above the fork height, and the assets and configs which are changed since.
+func rederiveGovernanceState(caller governanceCaller, forkHeight int64, assets []*asset.Asset, configs []*sysconfig.SysConfig) (
+	deletedAssets, updatedAssets []*asset.Asset, updatedConfigs []*sysconfig.SysConfig, err error) {
+	opts := &bind.CallOpts{BlockNumber: big.NewInt(forkHeight)}
+	for _, l2Asset := range assets {
+		if l2Asset.AssetId == types.BNBAssetId {
+			continue
+		}
+		assetId, err := caller.AssetsList(opts, common.HexToAddress(l2Asset.L1Address))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if uint32(assetId) != l2Asset.AssetId {
+			deletedAssets = append(deletedAssets, l2Asset)
+			continue
+		}
+		paused, err := caller.PausedAssets(opts, assetId)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		status := asset.StatusActive
+		if paused {
+			status = asset.StatusInactive
+		}
+		if l2Asset.Status != status {
+			updatedAsset := *l2Asset
+			updatedAsset.Status = status
+			updatedAssets = append(updatedAssets, &updatedAsset)
+		}
+	}
+
+	for _, config := range configs {
+		var value string
+		switch config.Name {
+		case types.Governor:
+			governor, err := caller.NetworkGovernor(opts)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			value = governor.Hex()
+		case types.AssetGovernanceContract:
+			assetGovernance, err := caller.AssetGovernance(opts)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			value = assetGovernance.Hex()
+		case types.Validators:
+			var validators map[string]*ValidatorInfo
+			err = json.Unmarshal([]byte(config.Value), &validators)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			for address, validator := range validators {
+				validator.IsActive, err = caller.Validators(opts, common.HexToAddress(address))
+				if err != nil {
+					return nil, nil, nil, err
+				}
+			}
+			validatorsBytes, err := json.Marshal(validators)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			value = string(validatorsBytes)
+		default:
+			continue
+		}
+		if config.Value != value {
+			updatedConfig := *config
+			updatedConfig.Value = value
+			updatedConfigs = append(updatedConfigs, &updatedConfig)
+		}
+	}
+	return deletedAssets, updatedAssets, updatedConfigs, nil
+}
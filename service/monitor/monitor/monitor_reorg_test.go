@@ -0,0 +1,142 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package monitor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/dao/asset"
+	"github.com/bnb-chain/zkbnb/dao/l1syncedblock"
+	"github.com/bnb-chain/zkbnb/dao/sysconfig"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+// forkGovernance is the governance contract state at the fork height.
+type forkGovernance struct {
+	assets          map[common.Address]uint16
+	pausedAssets    map[uint16]bool
+	governor        common.Address
+	assetGovernance common.Address
+	validators      map[common.Address]bool
+}
+
+func (g *forkGovernance) AssetsList(_ *bind.CallOpts, assetAddress common.Address) (uint16, error) {
+	return g.assets[assetAddress], nil
+}
+
+func (g *forkGovernance) PausedAssets(_ *bind.CallOpts, assetId uint16) (bool, error) {
+	return g.pausedAssets[assetId], nil
+}
+
+func (g *forkGovernance) NetworkGovernor(_ *bind.CallOpts) (common.Address, error) {
+	return g.governor, nil
+}
+
+func (g *forkGovernance) AssetGovernance(_ *bind.CallOpts) (common.Address, error) {
+	return g.assetGovernance, nil
+}
+
+func (g *forkGovernance) Validators(_ *bind.CallOpts, validator common.Address) (bool, error) {
+	return g.validators[validator], nil
+}
+
+func TestOrphanedBlockTxHashes(t *testing.T) {
+	eventsInfo := func(events ...*L1EventInfo) string {
+		bz, err := json.Marshal(events)
+		assert.NoError(t, err)
+		return string(bz)
+	}
+	committed, verified, err := orphanedBlockTxHashes([]*l1syncedblock.L1SyncedBlock{
+		{L1BlockHeight: 101, BlockInfo: eventsInfo(
+			&L1EventInfo{EventType: EventTypeNewPriorityRequest, TxHash: "0x1"},
+			&L1EventInfo{EventType: EventTypeCommittedBlock, TxHash: "0x2"},
+		)},
+		{L1BlockHeight: 102, BlockInfo: ""},
+		{L1BlockHeight: 103, BlockInfo: eventsInfo(
+			&L1EventInfo{EventType: EventTypeVerifiedBlock, TxHash: "0x3"},
+			&L1EventInfo{EventType: EventTypeCommittedBlock, TxHash: "0x4"},
+			&L1EventInfo{EventType: EventTypeRevertedBlock, TxHash: "0x5"},
+		)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0x2", "0x4"}, committed)
+	assert.Equal(t, []string{"0x3"}, verified)
+}
+
+func TestRederiveGovernanceState(t *testing.T) {
+	var (
+		token1    = common.HexToAddress("0x01")
+		token2    = common.HexToAddress("0x02")
+		token3    = common.HexToAddress("0x03")
+		governor  = common.HexToAddress("0x10")
+		validator = common.HexToAddress("0x20")
+		orphaned  = common.HexToAddress("0x21")
+	)
+	caller := &forkGovernance{
+		assets:          map[common.Address]uint16{token1: 1, token2: 2},
+		pausedAssets:    map[uint16]bool{2: true},
+		governor:        governor,
+		assetGovernance: common.HexToAddress("0x11"),
+		validators:      map[common.Address]bool{validator: true},
+	}
+	assets := []*asset.Asset{
+		{AssetId: types.BNBAssetId, Status: asset.StatusActive},
+		// paused by an orphaned block
+		{AssetId: 1, L1Address: token1.Hex(), Status: asset.StatusInactive},
+		// unpaused by an orphaned block
+		{AssetId: 2, L1Address: token2.Hex(), Status: asset.StatusActive},
+		// registered by an orphaned block
+		{AssetId: 3, L1Address: token3.Hex(), Status: asset.StatusActive},
+	}
+	validators, err := json.Marshal(map[string]*ValidatorInfo{
+		validator.Hex(): {Address: validator.Hex(), IsActive: true},
+		orphaned.Hex():  {Address: orphaned.Hex(), IsActive: true},
+	})
+	assert.NoError(t, err)
+	configs := []*sysconfig.SysConfig{
+		{Name: types.Governor, Value: common.HexToAddress("0x12").Hex()},
+		{Name: types.AssetGovernanceContract, Value: common.HexToAddress("0x11").Hex()},
+		{Name: types.Validators, Value: string(validators)},
+		{Name: types.ZkBNBContract, Value: "0x30"},
+	}
+
+	deletedAssets, updatedAssets, updatedConfigs, err := rederiveGovernanceState(caller, 100, assets, configs)
+	assert.NoError(t, err)
+	assert.Equal(t, []*asset.Asset{assets[3]}, deletedAssets)
+	assert.Len(t, updatedAssets, 2)
+	assert.Equal(t, uint32(1), updatedAssets[0].AssetId)
+	assert.Equal(t, asset.StatusActive, updatedAssets[0].Status)
+	assert.Equal(t, uint32(2), updatedAssets[1].AssetId)
+	assert.Equal(t, asset.StatusInactive, updatedAssets[1].Status)
+	// The synced records are not changed before they are stored.
+	assert.Equal(t, asset.StatusInactive, assets[1].Status)
+
+	assert.Len(t, updatedConfigs, 2)
+	assert.Equal(t, types.Governor, updatedConfigs[0].Name)
+	assert.Equal(t, governor.Hex(), updatedConfigs[0].Value)
+	assert.Equal(t, types.Validators, updatedConfigs[1].Name)
+	var rederived map[string]*ValidatorInfo
+	assert.NoError(t, json.Unmarshal([]byte(updatedConfigs[1].Value), &rederived))
+	assert.True(t, rederived[validator.Hex()].IsActive)
+	assert.False(t, rederived[orphaned.Hex()].IsActive)
+}
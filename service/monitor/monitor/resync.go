@@ -0,0 +1,603 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	zkbnb "github.com/bnb-chain/zkbnb-eth-rpc/zkbnb/core/legend"
+	common2 "github.com/bnb-chain/zkbnb/common"
+	"github.com/bnb-chain/zkbnb/dao/asset"
+	"github.com/bnb-chain/zkbnb/dao/l1syncedblock"
+	"github.com/bnb-chain/zkbnb/dao/priorityrequest"
+	"github.com/bnb-chain/zkbnb/dao/sysconfig"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	RecordL1SyncedBlock   = "l1_synced_block"
+	RecordPriorityRequest = "priority_request"
+	RecordAsset           = "asset"
+	RecordSysConfig       = "sys_config"
+)
+
+// Discrepancy is a difference between the logs on L1 and the stored records.
+type Discrepancy struct {
+	Record   string
+	Key      string
+	Detail   string
+	Repaired bool
+}
+
+func (d *Discrepancy) String() string {
+	s := fmt.Sprintf("%s %s: %s", d.Record, d.Key, d.Detail)
+	if d.Repaired {
+		s += " (repaired)"
+	}
+	return s
+}
+
+type resyncer struct {
+	m             *Monitor
+	repair        bool
+	discrepancies []*Discrepancy
+}
+
+// Resync fetches the logs of the l1 blocks from `from` to `to` again and compares them with the stored records,
+// the blocks which are not synced yet are left out. With repair, the discrepancies are repaired if it is safe:
+// the handled priority requests are never changed, so that no duplicate mempool txs are created. The monitor
+// should be stopped while repairing.
+func (m *Monitor) Resync(from, to int64, repair bool) ([]*Discrepancy, error) {
+	r := &resyncer{m: m, repair: repair}
+	err := r.resync(l1syncedblock.TypeGeneric, m.zkbnbContractAddress, from, to)
+	if err != nil {
+		return r.discrepancies, err
+	}
+	err = r.resync(l1syncedblock.TypeGovernance, m.governanceContractAddress, from, to)
+	return r.discrepancies, err
+}
+
+// report records the discrepancy, and repairs it if repair is set. The discrepancy can't be repaired
+// safely if repairFunc is nil.
+func (r *resyncer) report(record, key, detail string, repairFunc func() error) error {
+	d := &Discrepancy{Record: record, Key: key, Detail: detail}
+	r.discrepancies = append(r.discrepancies, d)
+	if !r.repair || repairFunc == nil {
+		return nil
+	}
+	err := repairFunc()
+	if err != nil {
+		return fmt.Errorf("failed to repair %s %s, err: %v", record, key, err)
+	}
+	d.Repaired = true
+	return nil
+}
+
+func (r *resyncer) resync(blockType int, contractAddress string, from, to int64) error {
+	latestSyncedBlock, err := r.m.L1SyncedBlockModel.GetLatestL1BlockByType(blockType)
+	if err == types.DbErrNotFound {
+		logx.Infof("no l1 blocks of type %d are synced", blockType)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get latest synced block, err: %v", err)
+	}
+	if to > latestSyncedBlock.L1BlockHeight {
+		logx.Infof("l1 blocks of type %d are synced up to %d", blockType, latestSyncedBlock.L1BlockHeight)
+		to = latestSyncedBlock.L1BlockHeight
+	}
+	if from > to {
+		return nil
+	}
+	logs, err := r.m.getLogs(contractAddress, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to get contract logs, err: %v", err)
+	}
+	err = r.checkSyncedBlocks(blockType, from, to, logs)
+	if err != nil {
+		return err
+	}
+	if blockType == l1syncedblock.TypeGeneric {
+		return r.checkPriorityRequests(from, to, logs)
+	}
+	return r.checkGovernance(logs, to == latestSyncedBlock.L1BlockHeight)
+}
+
+// getLogs gets the logs of the contract in chunks of MaxHandledBlocksCount blocks.
+func (m *Monitor) getLogs(contractAddress string, from, to int64) ([]ethTypes.Log, error) {
+	step := m.Config.ChainConfig.MaxHandledBlocksCount
+	if step <= 0 {
+		step = to - from + 1
+	}
+	var logs []ethTypes.Log
+	for start := from; start <= to; start += step {
+		end := common2.MinInt64(start+step-1, to)
+		chunk, err := getZkBNBContractLogs(m.cli, contractAddress, uint64(start), uint64(end))
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, chunk...)
+	}
+	return logs, nil
+}
+
+// l1EventType returns the type of the event which is recorded in the synced block for the log.
+func l1EventType(blockType int, topic ethCommon.Hash) (eventType uint8, recorded bool) {
+	if blockType == l1syncedblock.TypeGeneric {
+		switch topic {
+		case zkbnbLogBlockCommitSigHash:
+			return EventTypeCommittedBlock, true
+		case zkbnbLogBlockVerificationSigHash:
+			return EventTypeVerifiedBlock, true
+		case zkbnbLogBlocksRevertSigHash:
+			return EventTypeRevertedBlock, true
+		default:
+			return EventTypeNewPriorityRequest, true
+		}
+	}
+	switch topic {
+	case governanceLogNewAssetSigHash:
+		return EventTypeAddAsset, true
+	case governanceLogNewGovernorSigHash:
+		return EventTypeNewGovernor, true
+	case governanceLogNewAssetGovernanceSigHash:
+		return EventTypeNewAssetGovernance, true
+	case governanceLogValidatorStatusUpdateSigHash:
+		return EventTypeValidatorStatusUpdate, true
+	case governanceLogAssetPausedUpdateSigHash:
+		return EventTypeAssetPausedUpdate, true
+	default:
+		return 0, false
+	}
+}
+
+// missingEvents returns the expected events which are not recorded.
+func missingEvents(expected, recorded []*L1EventInfo) []*L1EventInfo {
+	counts := make(map[L1EventInfo]int, len(recorded))
+	for _, event := range recorded {
+		counts[*event]++
+	}
+	var missing []*L1EventInfo
+	for _, event := range expected {
+		if counts[*event] > 0 {
+			counts[*event]--
+			continue
+		}
+		missing = append(missing, event)
+	}
+	return missing
+}
+
+func (r *resyncer) checkSyncedBlocks(blockType int, from, to int64, logs []ethTypes.Log) error {
+	syncedBlocks, err := r.m.L1SyncedBlockModel.GetL1SyncedBlocksCovering(blockType, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to get synced blocks, err: %v", err)
+	}
+	// the events of the logs by the synced blocks which cover them
+	expected := make([][]*L1EventInfo, len(syncedBlocks))
+	i := 0
+	for _, vlog := range logs {
+		eventType, recorded := l1EventType(blockType, vlog.Topics[0])
+		if !recorded {
+			continue
+		}
+		for i < len(syncedBlocks) && uint64(syncedBlocks[i].L1BlockHeight) < vlog.BlockNumber {
+			i++
+		}
+		if i == len(syncedBlocks) {
+			break
+		}
+		expected[i] = append(expected[i], &L1EventInfo{EventType: eventType, TxHash: vlog.TxHash.Hex()})
+	}
+
+	for i, syncedBlock := range syncedBlocks {
+		var recorded []*L1EventInfo
+		if syncedBlock.BlockInfo != "" {
+			err = json.Unmarshal([]byte(syncedBlock.BlockInfo), &recorded)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal synced block %d, err: %v", syncedBlock.L1BlockHeight, err)
+			}
+		}
+		var details []string
+		missing := missingEvents(expected[i], recorded)
+		for _, event := range missing {
+			details = append(details, fmt.Sprintf("event %d of tx %s is not recorded", event.EventType, event.TxHash))
+		}
+		canonicalHash := syncedBlock.L1BlockHash
+		if syncedBlock.L1BlockHash != "" && syncedBlock.L1BlockHeight <= to {
+			header, err := r.m.cli.GetBlockHeaderByNumber(big.NewInt(syncedBlock.L1BlockHeight))
+			if err != nil {
+				return fmt.Errorf("failed to get block header, err: %v", err)
+			}
+			canonicalHash = header.Hash().Hex()
+			if canonicalHash != syncedBlock.L1BlockHash {
+				details = append(details, fmt.Sprintf("hash %s is not canonical %s", syncedBlock.L1BlockHash, canonicalHash))
+			}
+		}
+		if len(details) == 0 {
+			continue
+		}
+
+		syncedBlock := syncedBlock
+		err = r.report(RecordL1SyncedBlock, strconv.FormatInt(syncedBlock.L1BlockHeight, 10),
+			strings.Join(details, ", "), func() error {
+				eventInfosBytes, err := json.Marshal(append(recorded, missing...))
+				if err != nil {
+					return err
+				}
+				syncedBlock.BlockInfo = string(eventInfosBytes)
+				syncedBlock.L1BlockHash = canonicalHash
+				return r.m.db.Transaction(func(tx *gorm.DB) error {
+					return r.m.L1SyncedBlockModel.UpdateL1SyncedBlockInTransact(tx, syncedBlock)
+				})
+			})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// priorityRequestDiff returns the differences of the stored request from the one on L1.
+func priorityRequestDiff(stored, request *priorityrequest.PriorityRequest) string {
+	var diffs []string
+	diff := func(field string, storedValue, value interface{}) {
+		if storedValue != value {
+			diffs = append(diffs, fmt.Sprintf("%s is %v instead of %v", field, storedValue, value))
+		}
+	}
+	diff("l1 tx hash", stored.L1TxHash, request.L1TxHash)
+	diff("l1 block height", stored.L1BlockHeight, request.L1BlockHeight)
+	diff("sender", stored.SenderAddress, request.SenderAddress)
+	diff("tx type", stored.TxType, request.TxType)
+	diff("pubdata", stored.Pubdata, request.Pubdata)
+	diff("expiration block", stored.ExpirationBlock, request.ExpirationBlock)
+	return strings.Join(diffs, ", ")
+}
+
+func (r *resyncer) checkPriorityRequests(from, to int64, logs []ethTypes.Log) error {
+	storedRequests, err := r.m.PriorityRequestModel.GetPriorityRequestsBetweenL1Heights(from, to)
+	if err != nil && err != types.DbErrNotFound {
+		return fmt.Errorf("failed to get priority requests, err: %v", err)
+	}
+	unmatched := make(map[int64]*priorityrequest.PriorityRequest, len(storedRequests))
+	for _, storedRequest := range storedRequests {
+		unmatched[storedRequest.RequestId] = storedRequest
+	}
+	latestHandledRequestId, err := r.m.PriorityRequestModel.GetLatestHandledRequestId()
+	if err != nil {
+		return fmt.Errorf("failed to get latest handled request id, err: %v", err)
+	}
+
+	for _, vlog := range logs {
+		if vlog.Topics[0] != zkbnbLogNewPriorityRequestSigHash {
+			continue
+		}
+		request, err := convertLogToNewPriorityRequestEvent(vlog)
+		if err != nil {
+			return fmt.Errorf("failed to convert NewPriorityRequest log, err: %v", err)
+		}
+		key := strconv.FormatInt(request.RequestId, 10)
+		storedRequest := unmatched[request.RequestId]
+		delete(unmatched, request.RequestId)
+		if storedRequest == nil {
+			// the request may be stored at another height
+			storedRequest, err = r.m.PriorityRequestModel.GetPriorityRequestByRequestId(request.RequestId)
+			if err != nil && err != types.DbErrNotFound {
+				return fmt.Errorf("failed to get priority request, err: %v", err)
+			}
+		}
+		if storedRequest == nil {
+			err = r.reportMissingRequest(request, latestHandledRequestId)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		detail := priorityRequestDiff(storedRequest, request)
+		if detail == "" {
+			continue
+		}
+		var repairFunc func() error
+		if storedRequest.Status == priorityrequest.PendingStatus {
+			repairFunc = func() error {
+				return r.m.db.Transaction(func(tx *gorm.DB) error {
+					err := r.m.PriorityRequestModel.DeletePriorityRequestsInTransact(tx,
+						[]*priorityrequest.PriorityRequest{storedRequest})
+					if err != nil {
+						return err
+					}
+					return r.m.PriorityRequestModel.CreatePriorityRequestsInTransact(tx,
+						[]*priorityrequest.PriorityRequest{request})
+				})
+			}
+		} else {
+			detail += ", the request is handled and has to be repaired manually"
+		}
+		err = r.report(RecordPriorityRequest, key, detail, repairFunc)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, storedRequest := range storedRequests {
+		if unmatched[storedRequest.RequestId] == nil {
+			continue
+		}
+		storedRequest := storedRequest
+		detail := "the request is not found on L1"
+		var repairFunc func() error
+		if storedRequest.Status == priorityrequest.PendingStatus {
+			repairFunc = func() error {
+				return r.m.db.Transaction(func(tx *gorm.DB) error {
+					return r.m.PriorityRequestModel.DeletePriorityRequestsInTransact(tx,
+						[]*priorityrequest.PriorityRequest{storedRequest})
+				})
+			}
+		} else {
+			detail += ", the request is handled and has to be repaired manually"
+		}
+		err = r.report(RecordPriorityRequest, strconv.FormatInt(storedRequest.RequestId, 10), detail, repairFunc)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportMissingRequest reports the request which is not stored. The request is stored as handled if its
+// mempool tx exists, it can't be stored if it is older than the handled requests as they are handled in order.
+func (r *resyncer) reportMissingRequest(request *priorityrequest.PriorityRequest, latestHandledRequestId int64) error {
+	mempoolTxs, err := r.m.MempoolModel.GetMempoolTxsByTxHashes(
+		[]string{ComputeL1TxTxHash(request.RequestId, request.L1TxHash)})
+	if err != nil {
+		return fmt.Errorf("failed to get mempool txs, err: %v", err)
+	}
+	detail := "the request is not stored"
+	var repairFunc func() error
+	switch {
+	case len(mempoolTxs) > 0:
+		detail += ", its mempool tx exists"
+		request.Status = priorityrequest.HandledStatus
+	case request.RequestId <= latestHandledRequestId:
+		detail += fmt.Sprintf(", it has to be repaired manually as request %d is handled", latestHandledRequestId)
+	}
+	if request.Status == priorityrequest.HandledStatus || request.RequestId > latestHandledRequestId {
+		repairFunc = func() error {
+			return r.m.db.Transaction(func(tx *gorm.DB) error {
+				return r.m.PriorityRequestModel.CreatePriorityRequestsInTransact(tx,
+					[]*priorityrequest.PriorityRequest{request})
+			})
+		}
+	}
+	return r.report(RecordPriorityRequest, strconv.FormatInt(request.RequestId, 10), detail, repairFunc)
+}
+
+// checkGovernance checks the assets registered in the logs. The asset status and the governance configs are
+// checked against the last events only if the logs reach the latest synced block, as they may be changed later.
+func (r *resyncer) checkGovernance(logs []ethTypes.Log, latest bool) error {
+	var (
+		governor, assetGovernance string
+		pausedAssets              = make(map[string]bool)
+		validators                = make(map[string]bool)
+	)
+	for _, vlog := range logs {
+		switch vlog.Topics[0] {
+		case governanceLogNewAssetSigHash:
+			var event zkbnb.GovernanceNewAsset
+			if err := GovernanceContractAbi.UnpackIntoInterface(&event, EventNameNewAsset, vlog.Data); err != nil {
+				return fmt.Errorf("unpackIntoInterface err: %v", err)
+			}
+			err := r.checkAsset(event.AssetId, event.AssetAddress)
+			if err != nil {
+				return err
+			}
+		case governanceLogNewGovernorSigHash:
+			var event zkbnb.GovernanceNewGovernor
+			if err := GovernanceContractAbi.UnpackIntoInterface(&event, EventNameNewGovernor, vlog.Data); err != nil {
+				return fmt.Errorf("unpackIntoInterface err: %v", err)
+			}
+			governor = event.NewGovernor.Hex()
+		case governanceLogNewAssetGovernanceSigHash:
+			var event zkbnb.GovernanceNewAssetGovernance
+			if err := GovernanceContractAbi.UnpackIntoInterface(&event, EventNameNewAssetGovernance, vlog.Data); err != nil {
+				return fmt.Errorf("unpackIntoInterface err: %v", err)
+			}
+			assetGovernance = event.NewAssetGovernance.Hex()
+		case governanceLogValidatorStatusUpdateSigHash:
+			var event zkbnb.GovernanceValidatorStatusUpdate
+			if err := GovernanceContractAbi.UnpackIntoInterface(&event, EventNameValidatorStatusUpdate, vlog.Data); err != nil {
+				return fmt.Errorf("unpackIntoInterface err: %v", err)
+			}
+			validators[event.ValidatorAddress.Hex()] = event.IsActive
+		case governanceLogAssetPausedUpdateSigHash:
+			var event zkbnb.GovernanceAssetPausedUpdate
+			if err := GovernanceContractAbi.UnpackIntoInterface(&event, EventNameAssetPausedUpdate, vlog.Data); err != nil {
+				return fmt.Errorf("unpackIntoInterface err: %v", err)
+			}
+			pausedAssets[event.Token.Hex()] = event.Paused
+		}
+	}
+	if !latest {
+		logx.Info("the asset status and governance configs are not checked as the range does not reach the latest synced block")
+		return nil
+	}
+
+	for _, address := range sortedKeys(pausedAssets) {
+		err := r.checkAssetStatus(address, pausedAssets[address])
+		if err != nil {
+			return err
+		}
+	}
+	if governor != "" {
+		err := r.checkSysConfig(types.Governor, governor, "governor")
+		if err != nil {
+			return err
+		}
+	}
+	if assetGovernance != "" {
+		err := r.checkSysConfig(types.AssetGovernanceContract, assetGovernance, "asset governance contract")
+		if err != nil {
+			return err
+		}
+	}
+	for _, address := range sortedKeys(validators) {
+		err := r.checkValidator(address, validators[address])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *resyncer) checkAsset(assetId uint16, assetAddress ethCommon.Address) error {
+	key := strconv.Itoa(int(assetId))
+	storedAsset, err := r.m.L2AssetModel.GetAssetById(int64(assetId))
+	if err == types.DbErrNotFound {
+		return r.report(RecordAsset, key, "the asset is not stored", func() error {
+			l2Asset, err := r.m.newL2Asset(assetId, assetAddress)
+			if err != nil {
+				return err
+			}
+			return r.m.db.Transaction(func(tx *gorm.DB) error {
+				return r.m.L2AssetModel.CreateAssetsInTransact(tx, []*asset.Asset{l2Asset})
+			})
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get asset, err: %v", err)
+	}
+	if storedAsset.L1Address != assetAddress.Hex() {
+		return r.report(RecordAsset, key, fmt.Sprintf("l1 address is %s instead of %s, it has to be repaired manually",
+			storedAsset.L1Address, assetAddress.Hex()), nil)
+	}
+	return nil
+}
+
+func (r *resyncer) checkAssetStatus(address string, paused bool) error {
+	status := asset.StatusActive
+	if paused {
+		status = asset.StatusInactive
+	}
+	storedAsset, err := r.m.L2AssetModel.GetAssetByAddress(address)
+	if err == types.DbErrNotFound {
+		return r.report(RecordAsset, address, "the paused asset is not stored", nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get asset, err: %v", err)
+	}
+	if storedAsset.Status == status {
+		return nil
+	}
+	return r.report(RecordAsset, address, fmt.Sprintf("status is %d instead of %d", storedAsset.Status, status),
+		func() error {
+			storedAsset.Status = status
+			return r.m.db.Transaction(func(tx *gorm.DB) error {
+				return r.m.L2AssetModel.UpdateAssetsInTransact(tx, []*asset.Asset{storedAsset})
+			})
+		})
+}
+
+func (r *resyncer) checkSysConfig(name, value, comment string) error {
+	storedConfig, err := r.m.SysConfigModel.GetSysConfigByName(name)
+	if err == types.DbErrNotFound {
+		return r.report(RecordSysConfig, name, "the config is not stored", func() error {
+			return r.m.db.Transaction(func(tx *gorm.DB) error {
+				return r.m.SysConfigModel.CreateSysConfigsInTransact(tx, []*sysconfig.SysConfig{{
+					Name:      name,
+					Value:     value,
+					ValueType: "string",
+					Comment:   comment,
+				}})
+			})
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get sys config, err: %v", err)
+	}
+	if storedConfig.Value == value {
+		return nil
+	}
+	return r.report(RecordSysConfig, name, fmt.Sprintf("value is %s instead of %s", storedConfig.Value, value),
+		func() error {
+			storedConfig.Value = value
+			return r.m.db.Transaction(func(tx *gorm.DB) error {
+				return r.m.SysConfigModel.UpdateSysConfigsInTransact(tx, []*sysconfig.SysConfig{storedConfig})
+			})
+		})
+}
+
+func (r *resyncer) checkValidator(address string, isActive bool) error {
+	storedConfig, err := r.m.SysConfigModel.GetSysConfigByName(types.Validators)
+	if err != nil && err != types.DbErrNotFound {
+		return fmt.Errorf("failed to get sys config, err: %v", err)
+	}
+	validators := make(map[string]*ValidatorInfo)
+	if storedConfig != nil {
+		err = json.Unmarshal([]byte(storedConfig.Value), &validators)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal validators, err: %v", err)
+		}
+	}
+	validator := validators[address]
+	if validator != nil && validator.IsActive == isActive {
+		return nil
+	}
+	detail := "the validator is not stored"
+	if validator != nil {
+		detail = fmt.Sprintf("active is %t instead of %t", validator.IsActive, isActive)
+	}
+	return r.report(RecordSysConfig, types.Validators+" "+address, detail, func() error {
+		validators[address] = &ValidatorInfo{Address: address, IsActive: isActive}
+		validatorsBytes, err := json.Marshal(validators)
+		if err != nil {
+			return err
+		}
+		return r.m.db.Transaction(func(tx *gorm.DB) error {
+			if storedConfig == nil {
+				return r.m.SysConfigModel.CreateSysConfigsInTransact(tx, []*sysconfig.SysConfig{{
+					Name:      types.Validators,
+					Value:     string(validatorsBytes),
+					ValueType: "map[string]*ValidatorInfo",
+					Comment:   "validator info",
+				}})
+			}
+			storedConfig.Value = string(validatorsBytes)
+			return r.m.SysConfigModel.UpdateSysConfigsInTransact(tx, []*sysconfig.SysConfig{storedConfig})
+		})
+	})
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/zkbnb/dao/priorityrequest"
+)
+
+func TestMissingEvents(t *testing.T) {
+	expected := []*L1EventInfo{
+		{EventType: EventTypeCommittedBlock, TxHash: "0x1"},
+		{EventType: EventTypeNewPriorityRequest, TxHash: "0x2"},
+		{EventType: EventTypeNewPriorityRequest, TxHash: "0x2"},
+		{EventType: EventTypeVerifiedBlock, TxHash: "0x3"},
+	}
+	recorded := []*L1EventInfo{
+		{EventType: EventTypeNewPriorityRequest, TxHash: "0x2"},
+		{EventType: EventTypeCommittedBlock, TxHash: "0x1"},
+	}
+	assert.Equal(t, []*L1EventInfo{
+		{EventType: EventTypeNewPriorityRequest, TxHash: "0x2"},
+		{EventType: EventTypeVerifiedBlock, TxHash: "0x3"},
+	}, missingEvents(expected, recorded))
+	assert.Empty(t, missingEvents(recorded, expected))
+}
+
+func TestPriorityRequestDiff(t *testing.T) {
+	request := &priorityrequest.PriorityRequest{
+		L1TxHash:        "0x1",
+		L1BlockHeight:   100,
+		SenderAddress:   "0xa",
+		RequestId:       1,
+		TxType:          2,
+		Pubdata:         "0x00",
+		ExpirationBlock: 200,
+	}
+	stored := *request
+	stored.Status = priorityrequest.HandledStatus
+	assert.Empty(t, priorityRequestDiff(&stored, request))
+
+	stored.L1BlockHeight = 99
+	stored.Pubdata = "0x01"
+	assert.Equal(t, "l1 block height is 99 instead of 100, pubdata is 0x01 instead of 0x00",
+		priorityRequestDiff(&stored, request))
+}
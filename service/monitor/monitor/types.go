@@ -32,6 +32,7 @@ const (
 	EventNameNewPriorityRequest = "NewPriorityRequest"
 	EventNameBlockCommit        = "BlockCommit"
 	EventNameBlockVerification  = "BlockVerification"
+	EventNameBlocksRevert       = "BlocksRevert"
 
 	EventTypeNewPriorityRequest = 0
 	EventTypeCommittedBlock     = 1
@@ -95,6 +96,12 @@ var (
 	governanceLogAssetPausedUpdateSigHash     = crypto.Keccak256Hash(governanceLogAssetPausedUpdateSig)
 )
 
+// ValidatorInfo is the value of the validators in the sysconfig.
+type ValidatorInfo struct {
+	Address  string
+	IsActive bool
+}
+
 type L1EventInfo struct {
 	// deposit / lock / committed / verified / reverted
 	EventType uint8
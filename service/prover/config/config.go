@@ -2,20 +2,40 @@ package config
 
 import (
 	"github.com/zeromicro/go-zero/core/logx"
-	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/prometheus"
+
+	"github.com/bnb-chain/zkbnb/common/storage"
 )
 
 type Config struct {
+	// Postgres is not required if the jobs are pulled from the coordinator.
 	Postgres struct {
 		DataSource string
-	}
-	CacheRedis cache.CacheConf
-	LogConf    logx.LogConf
-	KeyPath    struct {
+	} `json:",optional"`
+	Coordinator struct {
+		Url   string
+		Token string
+	} `json:",optional"`
+	LogConf logx.LogConf
+	KeyPath struct {
 		ProvingKeyPath   []string
 		VerifyingKeyPath []string
+		// Manifest of the keys, generated by `zkbnb keys manifest`.
+		ManifestPath string
+		// Verifier contract updated by verifier_parse.py.
+		VerifierPath string `json:",optional"`
 	}
 	BlockConfig struct {
 		OptionalBlockSizes []int
 	}
+	Worker struct {
+		// Unique id of the worker, hostname-pid is used if not set.
+		WorkerId string `json:",optional"`
+		// Lease timeout and heartbeat interval of the worker in seconds.
+		LeaseTimeout      int64 `json:",optional"`
+		HeartbeatInterval int64 `json:",optional"`
+	} `json:",optional"`
+	Prometheus prometheus.Config `json:",optional"`
+	// Blob storage of the witnesses, only used with Postgres, the witnesses are in Postgres if it is not set.
+	WitnessStorage storage.Config `json:",optional"`
 }
@@ -0,0 +1,183 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueue
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/zkbnb/common/prove"
+	"github.com/bnb-chain/zkbnb/dao/blockwitness"
+	"github.com/bnb-chain/zkbnb/dao/proof"
+	"github.com/bnb-chain/zkbnb/dao/proverworker"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+type dbJobQueue struct {
+	leaseTimeout time.Duration
+	witnessStore *prove.WitnessStore
+
+	blockWitnessModel blockwitness.BlockWitnessModel
+	proofModel        proof.ProofModel
+	proverWorkerModel proverworker.ProverWorkerModel
+}
+
+// NewDbJobQueue creates the job queue which works on the database directly.
+func NewDbJobQueue(db *gorm.DB, leaseTimeout time.Duration, witnessStore *prove.WitnessStore) JobQueue {
+	return &dbJobQueue{
+		leaseTimeout:      leaseTimeout,
+		witnessStore:      witnessStore,
+		blockWitnessModel: blockwitness.NewBlockWitnessModel(db),
+		proofModel:        proof.NewProofModel(db),
+		proverWorkerModel: proverworker.NewProverWorkerModel(db),
+	}
+}
+
+func (q *dbJobQueue) RegisterWorker(worker *Worker) error {
+	now := time.Now()
+	return q.proverWorkerModel.RegisterProverWorker(&proverworker.ProverWorker{
+		WorkerId:        worker.WorkerId,
+		Host:            worker.Host,
+		BlockSizes:      worker.BlockSizes,
+		LastHeartbeatAt: now,
+		ExpiredAt:       now.Add(q.leaseTimeout),
+	})
+}
+
+func (q *dbJobQueue) Heartbeat(workerId string) error {
+	now := time.Now()
+	err := q.proverWorkerModel.UpdateProverWorkerHeartbeat(workerId, now, now.Add(q.leaseTimeout))
+	if err == types.DbErrNotFound {
+		return ErrUnknownWorker
+	}
+	return err
+}
+
+// AcquireJob only leases the witnesses of the block sizes the worker registered, so that a witness
+// which the worker cannot prove is left to the other workers.
+func (q *dbJobQueue) AcquireJob(workerId string) (*Job, error) {
+	worker, err := q.proverWorkerModel.GetProverWorkerByWorkerId(workerId)
+	if err != nil {
+		if err == types.DbErrNotFound {
+			return nil, ErrUnknownWorker
+		}
+		return nil, err
+	}
+	blockSizes, err := parseBlockSizes(worker.BlockSizes)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		witness, err := q.blockWitnessModel.AcquireBlockWitnessLease(workerId, blockSizes, time.Now().Add(q.leaseTimeout))
+		if err != nil {
+			if err == types.DbErrNotFound {
+				return nil, ErrNoJob
+			}
+			return nil, err
+		}
+
+		// Skip the block witness which has been proved, e.g. by a worker whose lease was reclaimed.
+		_, err = q.proofModel.GetProofByBlockHeight(witness.Height)
+		if err == types.DbErrNotFound {
+			witnessData, err := q.witnessStore.LoadEncoded(witness)
+			if err != nil {
+				//nolint:errcheck
+				q.blockWitnessModel.ReleaseBlockWitnessLease(witness)
+				return nil, err
+			}
+			return &Job{
+				Height:      witness.Height,
+				WitnessData: witnessData,
+			}, nil
+		}
+		if err != nil {
+			//nolint:errcheck
+			q.blockWitnessModel.ReleaseBlockWitnessLease(witness)
+			return nil, err
+		}
+		err = q.blockWitnessModel.UpdateBlockWitnessStatus(witness, blockwitness.StatusProved)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (q *dbJobQueue) RenewJob(workerId string, height int64) error {
+	witness := &blockwitness.BlockWitness{Height: height, WorkerId: workerId}
+	err := q.blockWitnessModel.RenewBlockWitnessLease(witness, time.Now().Add(q.leaseTimeout))
+	if err == types.DbErrNotFound {
+		return ErrLeaseLost
+	}
+	return err
+}
+
+func (q *dbJobQueue) ReleaseJob(workerId string, height int64) error {
+	return q.blockWitnessModel.ReleaseBlockWitnessLease(&blockwitness.BlockWitness{Height: height, WorkerId: workerId})
+}
+
+// SubmitProof stores the proof of the block, the proof is accepted even if the lease has been
+// reclaimed as long as no proof of the block exists.
+func (q *dbJobQueue) SubmitProof(workerId string, height int64, formattedProof *prove.FormattedProof) error {
+	witness, err := q.blockWitnessModel.GetBlockWitnessByHeight(height)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.proofModel.GetProofByBlockHeight(height)
+	if err != nil && err != types.DbErrNotFound {
+		return err
+	}
+	if err == types.DbErrNotFound {
+		proofBytes, err := json.Marshal(formattedProof)
+		if err != nil {
+			return err
+		}
+		err = q.proofModel.CreateProof(&proof.Proof{
+			ProofInfo:   string(proofBytes),
+			BlockNumber: height,
+			Status:      proof.NotSent,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return q.blockWitnessModel.UpdateBlockWitnessStatus(witness, blockwitness.StatusProved)
+}
+
+// parseBlockSizes parses the comma separated block sizes of a worker.
+func parseBlockSizes(blockSizes string) ([]int, error) {
+	sizes := make([]int, 0)
+	for _, size := range strings.Split(blockSizes, ",") {
+		if size == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(size))
+		if err != nil {
+			return nil, fmt.Errorf("invalid block sizes %q, err: %v", blockSizes, err)
+		}
+		sizes = append(sizes, n)
+	}
+	if len(sizes) == 0 {
+		return nil, fmt.Errorf("worker has no block sizes")
+	}
+	return sizes, nil
+}
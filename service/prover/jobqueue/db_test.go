@@ -0,0 +1,34 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBlockSizes(t *testing.T) {
+	sizes, err := parseBlockSizes("1,8, 16")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 8, 16}, sizes)
+
+	_, err = parseBlockSizes("")
+	assert.Error(t, err)
+	_, err = parseBlockSizes("1,x")
+	assert.Error(t, err)
+}
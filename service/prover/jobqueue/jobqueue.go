@@ -0,0 +1,57 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueue
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/zkbnb/common/prove"
+)
+
+var (
+	ErrNoJob         = errors.New("no job")
+	ErrLeaseLost     = errors.New("lease of the job is lost")
+	ErrUnknownWorker = errors.New("unknown worker")
+)
+
+type (
+	Job struct {
+		Height int64 `json:"height"`
+		// The witness encoded by prove.EncodeBlockWitness.
+		WitnessData []byte `json:"witness_data"`
+	}
+
+	Worker struct {
+		WorkerId   string `json:"worker_id"`
+		Host       string `json:"host"`
+		BlockSizes string `json:"block_sizes"`
+	}
+
+	// JobQueue hands out the block witnesses to prover workers and collects their proofs.
+	JobQueue interface {
+		RegisterWorker(worker *Worker) error
+		// Heartbeat returns ErrUnknownWorker if the worker is not registered.
+		Heartbeat(workerId string) error
+		// AcquireJob leases the lowest unproved block witness of the block sizes of the worker, ErrNoJob
+		// is returned if there is none, and ErrUnknownWorker if the worker is not registered.
+		AcquireJob(workerId string) (*Job, error)
+		// RenewJob returns ErrLeaseLost if the lease has been reclaimed or taken by another worker.
+		RenewJob(workerId string, height int64) error
+		ReleaseJob(workerId string, height int64) error
+		SubmitProof(workerId string, height int64, proof *prove.FormattedProof) error
+	}
+)
@@ -0,0 +1,116 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/zkbnb/common/prove"
+)
+
+const remoteRequestTimeout = 30 * time.Second
+
+type remoteJobQueue struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewRemoteJobQueue creates the job queue which talks to the prover coordinator, so that
+// prover workers don't need to access the database.
+func NewRemoteJobQueue(url, token string) JobQueue {
+	return &remoteJobQueue{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+		client: &http.Client{Timeout: remoteRequestTimeout},
+	}
+}
+
+func (q *remoteJobQueue) RegisterWorker(worker *Worker) error {
+	return q.post(RegisterWorkerPath, worker, nil)
+}
+
+func (q *remoteJobQueue) Heartbeat(workerId string) error {
+	return q.post(HeartbeatPath, &ReqWorker{WorkerId: workerId}, nil)
+}
+
+func (q *remoteJobQueue) AcquireJob(workerId string) (*Job, error) {
+	resp := &RespAcquireJob{}
+	err := q.post(AcquireJobPath, &ReqWorker{WorkerId: workerId}, resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Job == nil {
+		return nil, ErrNoJob
+	}
+	return resp.Job, nil
+}
+
+func (q *remoteJobQueue) RenewJob(workerId string, height int64) error {
+	return q.post(RenewJobPath, &ReqJob{WorkerId: workerId, Height: height}, nil)
+}
+
+func (q *remoteJobQueue) ReleaseJob(workerId string, height int64) error {
+	return q.post(ReleaseJobPath, &ReqJob{WorkerId: workerId, Height: height}, nil)
+}
+
+func (q *remoteJobQueue) SubmitProof(workerId string, height int64, proof *prove.FormattedProof) error {
+	return q.post(SubmitProofPath, &ReqSubmitProof{WorkerId: workerId, Height: height, Proof: proof}, nil)
+}
+
+func (q *remoteJobQueue) post(path string, req, resp interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, q.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+q.token)
+
+	httpResp, err := q.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch httpResp.StatusCode {
+	case http.StatusOK:
+	case http.StatusConflict:
+		return ErrLeaseLost
+	case http.StatusNotFound:
+		return ErrUnknownWorker
+	default:
+		return fmt.Errorf("coordinator responds %d: %s", httpResp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	if resp == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, resp)
+}
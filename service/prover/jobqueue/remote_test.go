@@ -0,0 +1,67 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueue
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteJobQueue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case AcquireJobPath:
+			var req ReqWorker
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			resp := &RespAcquireJob{}
+			if req.WorkerId == "busy" {
+				resp.Job = &Job{Height: 10, WitnessData: []byte("{}")}
+			}
+			//nolint:errcheck
+			json.NewEncoder(w).Encode(resp)
+		case RenewJobPath:
+			w.WriteHeader(http.StatusConflict)
+		case HeartbeatPath:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			//nolint:errcheck
+			w.Write([]byte("{}"))
+		}
+	}))
+	defer server.Close()
+
+	queue := NewRemoteJobQueue(server.URL+"/", "token")
+	job, err := queue.AcquireJob("busy")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), job.Height)
+	_, err = queue.AcquireJob("idle")
+	assert.Equal(t, ErrNoJob, err)
+	assert.Equal(t, ErrLeaseLost, queue.RenewJob("busy", 10))
+	assert.Equal(t, ErrUnknownWorker, queue.Heartbeat("busy"))
+	assert.NoError(t, queue.ReleaseJob("busy", 10))
+
+	_, err = NewRemoteJobQueue(server.URL, "wrong").AcquireJob("busy")
+	assert.Error(t, err)
+}
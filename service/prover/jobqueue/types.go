@@ -0,0 +1,54 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueue
+
+import (
+	"github.com/bnb-chain/zkbnb/common/prove"
+)
+
+// The http protocol between remote prover workers and the coordinator, all the requests are
+// POST with json bodies and authenticated by the "Authorization: Bearer <token>" header.
+const (
+	RegisterWorkerPath = "/api/v1/worker/register"
+	HeartbeatPath      = "/api/v1/worker/heartbeat"
+	AcquireJobPath     = "/api/v1/job/acquire"
+	RenewJobPath       = "/api/v1/job/renew"
+	ReleaseJobPath     = "/api/v1/job/release"
+	SubmitProofPath    = "/api/v1/job/submit"
+)
+
+type (
+	ReqWorker struct {
+		WorkerId string `json:"worker_id"`
+	}
+
+	ReqJob struct {
+		WorkerId string `json:"worker_id"`
+		Height   int64  `json:"height"`
+	}
+
+	ReqSubmitProof struct {
+		WorkerId string                `json:"worker_id"`
+		Height   int64                 `json:"height"`
+		Proof    *prove.FormattedProof `json:"proof"`
+	}
+
+	// RespAcquireJob contains a nil job if there is no job.
+	RespAcquireJob struct {
+		Job *Job `json:"job"`
+	}
+)
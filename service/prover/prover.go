@@ -1,10 +1,13 @@
 package prover
 
 import (
+	"fmt"
+
 	"github.com/robfig/cron/v3"
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/proc"
+	"github.com/zeromicro/go-zero/core/prometheus"
 
 	"github.com/bnb-chain/zkbnb/service/prover/config"
 	"github.com/bnb-chain/zkbnb/service/prover/prover"
@@ -13,17 +16,36 @@ import (
 func Run(configFile string) error {
 	var c config.Config
 	conf.MustLoad(configFile, &c)
-	p := prover.NewProver(c)
+	p, err := prover.NewProver(c)
+	if err != nil {
+		return err
+	}
 	logx.MustSetup(c.LogConf)
 	logx.DisableStat()
 	proc.AddShutdownListener(func() {
 		logx.Close()
 	})
+	prometheus.StartAgent(c.Prometheus)
+
+	err = p.RegisterWorker()
+	if err != nil {
+		panic(err)
+	}
+	logx.Infof("prover worker %s is registered", p.WorkerId)
 
 	cronJob := cron.New(cron.WithChain(
 		cron.SkipIfStillRunning(cron.DiscardLogger),
 	))
-	_, err := cronJob.AddFunc("@every 10s", func() {
+	_, err = cronJob.AddFunc(fmt.Sprintf("@every %s", p.HeartbeatInterval), func() {
+		err := p.Heartbeat()
+		if err != nil {
+			logx.Errorf("failed to send prover worker heartbeat, %v", err)
+		}
+	})
+	if err != nil {
+		panic(err)
+	}
+	_, err = cronJob.AddFunc("@every 10s", func() {
 		logx.Info("start prover job......")
 		// cron job for receiving cryptoBlock and handling
 		err := p.ProveBlock()
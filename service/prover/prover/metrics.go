@@ -0,0 +1,31 @@
+package prover
+
+import (
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+const metricNamespace = "zkbnb"
+
+var (
+	provingDurationMetric = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "prover",
+		Name:      "proving_duration_ms",
+		Help:      "Duration of proving a block in milliseconds.",
+		Labels:    []string{"block_size"},
+		Buckets:   []float64{1000, 5000, 10000, 30000, 60000, 120000, 300000, 600000, 1200000},
+	})
+	provedBlocksMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "prover",
+		Name:      "blocks_total",
+		Help:      "Number of blocks whose proofs are submitted.",
+		Labels:    []string{"block_size"},
+	})
+	proveFailuresMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "prover",
+		Name:      "failures_total",
+		Help:      "Number of leased blocks which failed to be proved or submitted.",
+	})
+)
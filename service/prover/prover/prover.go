@@ -1,34 +1,29 @@
 package prover
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/zeromicro/go-zero/core/logx"
-	"github.com/zeromicro/go-zero/core/stores/redis"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
-	"github.com/bnb-chain/zkbnb-crypto/legend/circuit/bn254/block"
 	"github.com/bnb-chain/zkbnb/common/prove"
-	"github.com/bnb-chain/zkbnb/common/redislock"
-	"github.com/bnb-chain/zkbnb/dao/blockwitness"
-	"github.com/bnb-chain/zkbnb/dao/proof"
 	"github.com/bnb-chain/zkbnb/service/prover/config"
-	"github.com/bnb-chain/zkbnb/types"
+	"github.com/bnb-chain/zkbnb/service/prover/jobqueue"
 )
 
 type Prover struct {
 	Config config.Config
 
-	RedisConn *redis.Redis
-
-	ProofModel        proof.ProofModel
-	BlockWitnessModel blockwitness.BlockWitnessModel
+	WorkerId          string
+	HeartbeatInterval time.Duration
+	JobQueue          jobqueue.JobQueue
 
 	VerifyingKeys      []groth16.VerifyingKey
 	ProvingKeys        []groth16.ProvingKey
@@ -36,102 +31,171 @@ type Prover struct {
 	R1cs               []frontend.CompiledConstraintSystem
 }
 
-func WithRedis(redisType string, redisPass string) redis.Option {
-	return func(p *redis.Redis) {
-		p.Type = redisType
-		p.Pass = redisPass
-	}
-}
-func NewProver(c config.Config) *Prover {
-	db, err := gorm.Open(postgres.Open(c.Postgres.DataSource))
-	if err != nil {
-		logx.Errorf("gorm connect db error, err = %s", err.Error())
-	}
-	redisConn := redis.New(c.CacheRedis[0].Host, WithRedis(c.CacheRedis[0].Type, c.CacheRedis[0].Pass))
+func NewProver(c config.Config) (*Prover, error) {
 	prover := &Prover{
 		Config:            c,
-		RedisConn:         redisConn,
-		BlockWitnessModel: blockwitness.NewBlockWitnessModel(db),
-		ProofModel:        proof.NewProofModel(db),
+		WorkerId:          c.Worker.WorkerId,
+		HeartbeatInterval: time.Duration(c.Worker.HeartbeatInterval) * time.Second,
+	}
+	if prover.WorkerId == "" {
+		hostname, _ := os.Hostname()
+		prover.WorkerId = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	if prover.HeartbeatInterval <= 0 {
+		prover.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if c.Coordinator.Url != "" {
+		// Pull the jobs from the coordinator, the database is not accessed by the prover.
+		prover.JobQueue = jobqueue.NewRemoteJobQueue(c.Coordinator.Url, c.Coordinator.Token)
+	} else {
+		db, err := gorm.Open(postgres.Open(c.Postgres.DataSource))
+		if err != nil {
+			return nil, fmt.Errorf("gorm connect db error, err: %v", err)
+		}
+		leaseTimeout := time.Duration(c.Worker.LeaseTimeout) * time.Second
+		if leaseTimeout <= 0 {
+			leaseTimeout = DefaultLeaseTimeout
+		}
+		witnessStore, err := prove.NewWitnessStore(c.WitnessStorage)
+		if err != nil {
+			return nil, fmt.Errorf("witness storage init error, err: %v", err)
+		}
+		prover.JobQueue = jobqueue.NewDbJobQueue(db, leaseTimeout, witnessStore)
 	}
 
+	var err error
 	prover.OptionalBlockSizes = c.BlockConfig.OptionalBlockSizes
 	prover.ProvingKeys = make([]groth16.ProvingKey, len(prover.OptionalBlockSizes))
 	prover.VerifyingKeys = make([]groth16.VerifyingKey, len(prover.OptionalBlockSizes))
 	prover.R1cs = make([]frontend.CompiledConstraintSystem, len(prover.OptionalBlockSizes))
+	blockKeys := make([]*prove.BlockKeys, len(prover.OptionalBlockSizes))
 	for i := 0; i < len(prover.OptionalBlockSizes); i++ {
-		var circuit block.BlockConstraints
-		circuit.TxsCount = prover.OptionalBlockSizes[i]
-		circuit.Txs = make([]block.TxConstraints, circuit.TxsCount)
-		for i := 0; i < circuit.TxsCount; i++ {
-			circuit.Txs[i] = block.GetZeroTxConstraint()
-		}
-		logx.Infof("start compile block size %d circuit", circuit.TxsCount)
-		prover.R1cs[i], err = frontend.Compile(ecc.BN254, r1cs.NewBuilder, &circuit, frontend.IgnoreUnconstrainedInputs())
+		logx.Infof("start compile block size %d circuit", prover.OptionalBlockSizes[i])
+		prover.R1cs[i], err = prove.CompileBlockCircuit(prover.OptionalBlockSizes[i])
 		if err != nil {
-			panic("r1cs init error")
+			return nil, fmt.Errorf("r1cs init error, err: %v", err)
 		}
 		logx.Infof("circuit constraints: %d", prover.R1cs[i].GetNbConstraints())
 		logx.Info("finish compile circuit")
 		// read proving and verifying keys
 		prover.ProvingKeys[i], err = prove.LoadProvingKey(c.KeyPath.ProvingKeyPath[i])
 		if err != nil {
-			panic("provingKey loading error")
+			return nil, fmt.Errorf("load proving key %s error, err: %v", c.KeyPath.ProvingKeyPath[i], err)
 		}
 		prover.VerifyingKeys[i], err = prove.LoadVerifyingKey(c.KeyPath.VerifyingKeyPath[i])
 		if err != nil {
-			panic("verifyingKey loading error")
+			return nil, fmt.Errorf("load verifying key %s error, err: %v", c.KeyPath.VerifyingKeyPath[i], err)
+		}
+		blockKeys[i] = &prove.BlockKeys{
+			BlockSize:        prover.OptionalBlockSizes[i],
+			R1cs:             prover.R1cs[i],
+			ProvingKeyPath:   c.KeyPath.ProvingKeyPath[i],
+			VerifyingKeyPath: c.KeyPath.VerifyingKeyPath[i],
+			VerifyingKey:     prover.VerifyingKeys[i],
 		}
 	}
 
-	return prover
+	// Refuse to prove with keys which are misordered or don't match the on-chain verifier.
+	if c.KeyPath.ManifestPath == "" {
+		return nil, fmt.Errorf("key manifest is not configured")
+	}
+	err = prove.VerifyBlockKeys(c.KeyPath.ManifestPath, c.KeyPath.VerifierPath, blockKeys)
+	if err != nil {
+		return nil, fmt.Errorf("key integrity check failed, err: %v", err)
+	}
+
+	return prover, nil
 }
 
-func (p *Prover) ProveBlock() error {
-	blockWitness, err := func() (*blockwitness.BlockWitness, error) {
-		lock := redislock.GetRedisLockByKey(p.RedisConn, RedisLockKey)
-		err := redislock.TryAcquireLock(lock)
-		if err != nil {
-			return nil, err
-		}
-		//nolint:errcheck
-		defer lock.Release()
+// RegisterWorker registers the prover as a worker, so that it shows up in the status API.
+func (p *Prover) RegisterWorker() error {
+	hostname, _ := os.Hostname()
+	blockSizes := make([]string, 0, len(p.OptionalBlockSizes))
+	for _, size := range p.OptionalBlockSizes {
+		blockSizes = append(blockSizes, strconv.Itoa(size))
+	}
+	return p.JobQueue.RegisterWorker(&jobqueue.Worker{
+		WorkerId:   p.WorkerId,
+		Host:       hostname,
+		BlockSizes: strings.Join(blockSizes, ","),
+	})
+}
 
-		// Fetch unproved block witness.
-		blockWitness, err := p.BlockWitnessModel.GetLatestBlockWitness()
-		if err != nil {
-			return nil, err
-		}
-		// Update status of block witness.
-		err = p.BlockWitnessModel.UpdateBlockWitnessStatus(blockWitness, blockwitness.StatusReceived)
-		if err != nil {
-			return nil, err
-		}
-		return blockWitness, nil
-	}()
+// Heartbeat keeps the worker alive, the worker is registered again if it has been removed.
+func (p *Prover) Heartbeat() error {
+	err := p.JobQueue.Heartbeat(p.WorkerId)
+	if err == jobqueue.ErrUnknownWorker {
+		return p.RegisterWorker()
+	}
+	return err
+}
+
+func (p *Prover) ProveBlock() error {
+	// Lease the lowest unproved block, blocks leased by other workers are skipped.
+	job, err := p.JobQueue.AcquireJob(p.WorkerId)
 	if err != nil {
-		if err == types.DbErrNotFound {
+		if err == jobqueue.ErrNoJob {
 			return nil
 		}
+		if err == jobqueue.ErrUnknownWorker {
+			// The block sizes of the worker are needed to choose the job.
+			return p.RegisterWorker()
+		}
 		return err
 	}
-	defer func() {
-		if err == nil {
-			return
-		}
+	logx.Infof("worker %s leased block %d", p.WorkerId, job.Height)
 
-		// Recover block witness status.
-		res := p.BlockWitnessModel.UpdateBlockWitnessStatus(blockWitness, blockwitness.StatusPublished)
+	startedAt := time.Now()
+	stopRenew := make(chan struct{})
+	go p.renewLease(job.Height, stopRenew)
+	formattedProof, blockSize, err := p.proveJob(job)
+	close(stopRenew)
+	if err != nil {
+		proveFailuresMetric.Inc()
+		// Give the job back to the queue.
+		res := p.JobQueue.ReleaseJob(p.WorkerId, job.Height)
 		if res != nil {
-			logx.Errorf("revert block witness status failed, err %v", res)
+			logx.Errorf("release job failed, err %v", res)
 		}
-	}()
+		return err
+	}
 
+	err = p.JobQueue.SubmitProof(p.WorkerId, job.Height, formattedProof)
+	if err != nil {
+		proveFailuresMetric.Inc()
+		return fmt.Errorf("failed to submit proof of block %d, err: %v", job.Height, err)
+	}
+	provedBlocksMetric.Inc(strconv.Itoa(blockSize))
+	logx.Infof("worker %s proved block %d in %s", p.WorkerId, job.Height, time.Since(startedAt))
+	return nil
+}
+
+// renewLease extends the lease of the job periodically until stop is closed.
+func (p *Prover) renewLease(height int64, stop <-chan struct{}) {
+	ticker := time.NewTicker(p.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			err := p.JobQueue.RenewJob(p.WorkerId, height)
+			if err == jobqueue.ErrLeaseLost {
+				logx.Errorf("lease of block %d is lost", height)
+				return
+			} else if err != nil {
+				logx.Errorf("renew lease of block %d failed, err %v", height, err)
+			}
+		}
+	}
+}
+
+// proveJob returns the proof of the job and the size of the block.
+func (p *Prover) proveJob(job *jobqueue.Job) (*prove.FormattedProof, int, error) {
 	// Parse crypto block.
-	var cryptoBlock *block.Block
-	err = json.Unmarshal([]byte(blockWitness.WitnessData), &cryptoBlock)
+	cryptoBlock, err := prove.DecodeBlockWitness(job.WitnessData)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	var keyIndex int
@@ -141,38 +205,27 @@ func (p *Prover) ProveBlock() error {
 		}
 	}
 	if keyIndex == len(p.OptionalBlockSizes) {
-		return fmt.Errorf("can't find correct vk/pk")
+		return nil, 0, fmt.Errorf("can't find correct vk/pk")
 	}
 
 	// Generate proof.
+	blockSize := p.OptionalBlockSizes[keyIndex]
+	startedAt := time.Now()
 	blockProof, err := prove.GenerateProof(p.R1cs[keyIndex], p.ProvingKeys[keyIndex], p.VerifyingKeys[keyIndex], cryptoBlock)
+	provingDurationMetric.Observe(time.Since(startedAt).Milliseconds(), strconv.Itoa(blockSize))
 	if err != nil {
-		return fmt.Errorf("failed to generateProof, err: %v", err)
+		return nil, 0, fmt.Errorf("failed to generateProof, err: %v", err)
 	}
 
 	formattedProof, err := prove.FormatProof(blockProof, cryptoBlock.OldStateRoot, cryptoBlock.NewStateRoot, cryptoBlock.BlockCommitment)
 	if err != nil {
-		return fmt.Errorf("unable to format blockProof: %v", err)
+		return nil, 0, fmt.Errorf("unable to format blockProof: %v", err)
 	}
 
-	// Marshal formatted proof.
-	proofBytes, err := json.Marshal(formattedProof)
+	// Verify the proof as it will be submitted, so that a bad proof never reaches the database.
+	err = prove.VerifyProof(formattedProof, p.VerifyingKeys[keyIndex], cryptoBlock.OldStateRoot, cryptoBlock.NewStateRoot, cryptoBlock.BlockCommitment)
 	if err != nil {
-		return err
-	}
-
-	// Check the existence of block proof.
-	_, err = p.ProofModel.GetProofByBlockHeight(blockWitness.Height)
-	if err == nil {
-		logx.Errorf("blockProof of height %d exists", blockWitness.Height)
-		return nil
+		return nil, 0, fmt.Errorf("invalid proof of block %d, err: %v", job.Height, err)
 	}
-
-	var row = &proof.Proof{
-		ProofInfo:   string(proofBytes),
-		BlockNumber: blockWitness.Height,
-		Status:      proof.NotSent,
-	}
-	err = p.ProofModel.CreateProof(row)
-	return err
+	return formattedProof, blockSize, nil
 }
@@ -16,4 +16,9 @@
 
 package prover
 
-const RedisLockKey = "prover_mutex_key"
+import "time"
+
+const (
+	DefaultLeaseTimeout      = 10 * time.Minute
+	DefaultHeartbeatInterval = 30 * time.Second
+)
@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/prometheus"
 )
 
 type Config struct {
@@ -15,6 +16,28 @@ type Config struct {
 		ConfirmBlocksCount      uint64
 		Sk                      string
 		GasLimit                uint64
+		// Upper bound of the gas price in gwei, the gas price is not limited if it is 0. The gas limit
+		// is estimated for each rollup tx, and GasLimit is its upper bound.
+		MaxGasPrice uint64 `json:",optional"`
+		// Seconds before the pending rollup tx is replaced at a higher gas price, 60 by default.
+		GasPriceBumpTimeout int64 `json:",optional"`
+		// Percentage by which the gas price of the replacement tx is increased, 20 by default.
+		GasPriceBumpPercent int64 `json:",optional"`
+		// Upper bound of the calldata size of a commit tx in bytes, 120000 by default.
+		MaxCommitCalldataSize int `json:",optional"`
+		// Target cost of committing a block in gwei. The commit tx which can take more blocks waits up to
+		// MaxWaitingTime seconds for them if the cost is higher, the cost is not targeted if it is 0.
+		CommitCostTarget uint64 `json:",optional"`
 	}
-	LogConf logx.LogConf
+	// The proofs are verified with the keys before they are submitted to L1.
+	KeyPath struct {
+		VerifyingKeyPath []string
+		// Manifest of the keys, generated by `zkbnb keys manifest`.
+		ManifestPath string
+	}
+	BlockConfig struct {
+		OptionalBlockSizes []int
+	}
+	LogConf    logx.LogConf
+	Prometheus prometheus.Config `json:",optional"`
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/proc"
+	"github.com/zeromicro/go-zero/core/prometheus"
 
 	"github.com/bnb-chain/zkbnb/service/sender/config"
 	"github.com/bnb-chain/zkbnb/service/sender/sender"
@@ -19,6 +20,7 @@ func Run(configFile string) error {
 	proc.AddShutdownListener(func() {
 		logx.Close()
 	})
+	prometheus.StartAgent(c.Prometheus)
 
 	// new cron
 	cronJob := cron.New(cron.WithChain(
@@ -58,6 +60,11 @@ func Run(configFile string) error {
 		panic(err)
 	}
 
+	_, err = cronJob.AddFunc("@every 30s", s.CheckL1Endpoints)
+	if err != nil {
+		panic(err)
+	}
+
 	cronJob.Start()
 
 	logx.Info("cronjob is starting......")
@@ -0,0 +1,163 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sender
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	zkbnb "github.com/bnb-chain/zkbnb-eth-rpc/zkbnb/core/legend"
+	"github.com/bnb-chain/zkbnb/dao/compressedblock"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+const (
+	// BSC rejects the txs larger than 128KB, the rest is left for the signature and the envelope.
+	defaultMaxCommitCalldataSize = 120000
+)
+
+// commitBatch is the blocks chosen to be committed in one tx.
+type commitBatch struct {
+	blocks   []*compressedblock.CompressedBlock
+	infos    []zkbnb.OldZkBNBCommitBlockInfo
+	calldata []byte
+	gas      uint64
+}
+
+// chooseCommitBatch chooses the blocks from the start of the candidates to be committed now. The
+// batch is bounded by the calldata size and the gas limit, and a batch which is not full waits up
+// to MaxWaitingTime for more blocks when the cost per block exceeds the target. The batch with
+// priority operations is never delayed. Nil is returned if the sender should wait.
+func (s *Sender) chooseCommitBatch(lastStoredBlockInfo zkbnb.StorageStoredBlockInfo,
+	blocks []*compressedblock.CompressedBlock) (*commitBatch, error) {
+	if len(blocks) > s.config.ChainConfig.MaxBlockCount {
+		blocks = blocks[:s.config.ChainConfig.MaxBlockCount]
+	}
+	infos, err := ConvertBlocksForCommitToCommitBlockInfos(blocks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit block info, err: %v", err)
+	}
+
+	// Bound the batch by the calldata size, there is at least one block in the batch.
+	maxCalldataSize := s.config.ChainConfig.MaxCommitCalldataSize
+	if maxCalldataSize <= 0 {
+		maxCalldataSize = defaultMaxCommitCalldataSize
+	}
+	var batch *commitBatch
+	for n := len(blocks); n > 0; n-- {
+		calldata, err := ZkBNBContractAbi.Pack("commitBlocks", lastStoredBlockInfo, infos[:n])
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack commitBlocks call, err: %v", err)
+		}
+		batch = &commitBatch{blocks: blocks[:n], infos: infos[:n], calldata: calldata}
+		if len(calldata) <= maxCalldataSize {
+			break
+		}
+	}
+
+	// Bound the batch by the gas limit, the gas is assumed to be proportional to the blocks.
+	suggested, err := s.cli.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch gas price, err: %v", err)
+	}
+	gasPrice := s.gasStrategy.gasPrice(suggested)
+	gasLimit, err := s.blockGasLimit()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		batch.gas, err = s.estimateGas(batch.calldata, gasPrice)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas of commitBlocks call, err: %v", err)
+		}
+		n := len(batch.blocks)
+		if batch.gas <= gasLimit || n == 1 {
+			break
+		}
+		fitted := int(uint64(n) * gasLimit / batch.gas)
+		if fitted >= n {
+			fitted = n - 1
+		}
+		if fitted < 1 {
+			fitted = 1
+		}
+		batch.blocks, batch.infos = batch.blocks[:fitted], batch.infos[:fitted]
+		batch.calldata, err = ZkBNBContractAbi.Pack("commitBlocks", lastStoredBlockInfo, batch.infos)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack commitBlocks call, err: %v", err)
+		}
+	}
+
+	// The batch is full if it can't take more blocks.
+	if len(batch.blocks) < len(blocks) || len(batch.blocks) == s.config.ChainConfig.MaxBlockCount {
+		return batch, nil
+	}
+	for _, info := range batch.infos {
+		if hasPriorityOperations(info) {
+			return batch, nil
+		}
+	}
+	costTarget := s.config.ChainConfig.CommitCostTarget
+	if costTarget == 0 {
+		return batch, nil
+	}
+	// cost per block in gwei
+	cost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(batch.gas))
+	cost.Div(cost, big.NewInt(int64(len(batch.blocks))))
+	cost.Div(cost, gwei)
+	if cost.Cmp(new(big.Int).SetUint64(costTarget)) <= 0 {
+		return batch, nil
+	}
+	waited := time.Since(time.UnixMilli(batch.blocks[0].Timestamp))
+	if waited >= time.Duration(s.config.ChainConfig.MaxWaitingTime)*time.Second {
+		return batch, nil
+	}
+	logx.Infof("wait for more blocks to commit, %d blocks cost %s gwei per block, waited %s",
+		len(batch.blocks), cost, waited)
+	return nil, nil
+}
+
+// blockGasLimit returns the gas limit of a rollup tx, which is the lower one of the configured
+// gas limit and the gas limit of the latest L1 block.
+func (s *Sender) blockGasLimit() (uint64, error) {
+	header, err := s.cli.GetLatestBlockHeader()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest l1 block header, err: %v", err)
+	}
+	gasLimit := header.GasLimit
+	if s.config.ChainConfig.GasLimit > 0 && s.config.ChainConfig.GasLimit < gasLimit {
+		gasLimit = s.config.ChainConfig.GasLimit
+	}
+	return gasLimit, nil
+}
+
+// hasPriorityOperations checks the tx types at the pub data offsets of the block, the priority
+// requests from L1, including the full exits, are waiting to be committed.
+func hasPriorityOperations(info zkbnb.OldZkBNBCommitBlockInfo) bool {
+	for _, offset := range info.PublicDataOffsets {
+		switch info.PublicData[offset] {
+		case types.TxTypeRegisterZns, types.TxTypeCreatePair, types.TxTypeUpdatePairRate,
+			types.TxTypeDeposit, types.TxTypeDepositNft, types.TxTypeFullExit, types.TxTypeFullExitNft:
+			return true
+		}
+	}
+	return false
+}
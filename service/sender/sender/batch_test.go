@@ -0,0 +1,41 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sender
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	zkbnb "github.com/bnb-chain/zkbnb-eth-rpc/zkbnb/core/legend"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+func TestHasPriorityOperations(t *testing.T) {
+	pubData := make([]byte, 3*32)
+	pubData[0] = types.TxTypeTransfer
+	info := zkbnb.OldZkBNBCommitBlockInfo{PublicData: pubData}
+	assert.False(t, hasPriorityOperations(info))
+
+	pubData[32] = types.TxTypeWithdraw
+	info.PublicDataOffsets = []uint32{32}
+	assert.False(t, hasPriorityOperations(info))
+
+	pubData[64] = types.TxTypeFullExit
+	info.PublicDataOffsets = []uint32{32, 64}
+	assert.True(t, hasPriorityOperations(info))
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"github.com/bnb-chain/zkbnb/dao/l1rolluptx"
+	sconfig "github.com/bnb-chain/zkbnb/service/sender/config"
+)
+
+const (
+	defaultGasPriceBumpTimeout = 60
+	defaultGasPriceBumpPercent = 20
+	// The estimated gas is increased by the margin, the gas used may change before the tx is packed.
+	gasLimitMarginPercent = 20
+)
+
+var (
+	gwei = big.NewInt(1e9)
+
+	errMaxGasPriceReached = errors.New("gas price reaches the max gas price")
+)
+
+// gasStrategy prices the rollup txs, the gas price follows the suggested one under the cap, and is
+// bumped for the replacement of the pending tx which is not packed in time.
+type gasStrategy struct {
+	maxGasPrice *big.Int
+	maxGasLimit uint64
+	bumpTimeout time.Duration
+	bumpPercent int64
+}
+
+func newGasStrategy(c sconfig.Config) *gasStrategy {
+	g := &gasStrategy{
+		maxGasLimit: c.ChainConfig.GasLimit,
+		bumpTimeout: time.Duration(c.ChainConfig.GasPriceBumpTimeout) * time.Second,
+		bumpPercent: c.ChainConfig.GasPriceBumpPercent,
+	}
+	if c.ChainConfig.MaxGasPrice > 0 {
+		g.maxGasPrice = new(big.Int).Mul(new(big.Int).SetUint64(c.ChainConfig.MaxGasPrice), gwei)
+	}
+	if g.bumpTimeout <= 0 {
+		g.bumpTimeout = defaultGasPriceBumpTimeout * time.Second
+	}
+	if g.bumpPercent <= 0 {
+		g.bumpPercent = defaultGasPriceBumpPercent
+	}
+	return g
+}
+
+func (g *gasStrategy) gasPrice(suggested *big.Int) *big.Int {
+	if g.maxGasPrice != nil && suggested.Cmp(g.maxGasPrice) > 0 {
+		return new(big.Int).Set(g.maxGasPrice)
+	}
+	return suggested
+}
+
+// bumpedGasPrice returns the gas price of the replacement tx, which is at least the suggested gas price.
+func (g *gasStrategy) bumpedGasPrice(current, suggested *big.Int) (*big.Int, error) {
+	bumped := new(big.Int).Mul(current, big.NewInt(100+g.bumpPercent))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(suggested) < 0 {
+		bumped.Set(suggested)
+	}
+	bumped = g.gasPrice(bumped)
+	if bumped.Cmp(current) <= 0 {
+		return nil, errMaxGasPriceReached
+	}
+	return bumped, nil
+}
+
+func (g *gasStrategy) gasLimit(estimated uint64) (uint64, error) {
+	gasLimit := estimated * (100 + gasLimitMarginPercent) / 100
+	if g.maxGasLimit > 0 && gasLimit > g.maxGasLimit {
+		if estimated > g.maxGasLimit {
+			return 0, fmt.Errorf("estimated gas %d exceeds the gas limit %d", estimated, g.maxGasLimit)
+		}
+		gasLimit = g.maxGasLimit
+	}
+	return gasLimit, nil
+}
+
+// sendRollupTx calls the method of the rollup contract, the gas is estimated for each call.
+func (s *Sender) sendRollupTx(method string, params ...interface{}) (*ethTypes.Transaction, error) {
+	data, err := ZkBNBContractAbi.Pack(method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call, err: %v", method, err)
+	}
+	return s.sendRollupCalldata(method, data)
+}
+
+func (s *Sender) sendRollupCalldata(method string, data []byte) (*ethTypes.Transaction, error) {
+	suggested, err := s.cli.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch gas price, err: %v", err)
+	}
+	gasPrice := s.gasStrategy.gasPrice(suggested)
+	estimated, err := s.estimateGas(data, gasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas of %s call, err: %v", method, err)
+	}
+	gasLimit, err := s.gasStrategy.gasLimit(estimated)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := s.cli.GetPendingNonce(s.authCli.Address.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce, err: %v", err)
+	}
+	return s.signAndSendTx(ethTypes.NewTransaction(nonce, s.zkbnbAddress, big.NewInt(0), gasLimit, gasPrice, data))
+}
+
+func (s *Sender) estimateGas(data []byte, gasPrice *big.Int) (uint64, error) {
+	return s.cli.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:     s.authCli.Address,
+		To:       &s.zkbnbAddress,
+		GasPrice: gasPrice,
+		Data:     data,
+	})
+}
+
+// replaceRollupTx sends the same call with the same nonce at a bumped gas price if the pending tx
+// is not packed before the timeout, the replaced tx hash is recorded with the rollup tx.
+func (s *Sender) replaceRollupTx(pendingTx *l1rolluptx.L1RollupTx, tx *ethTypes.Transaction) error {
+	if time.Since(pendingTx.UpdatedAt) < s.gasStrategy.bumpTimeout {
+		return nil
+	}
+	suggested, err := s.cli.SuggestGasPrice(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to fetch gas price, err: %v", err)
+	}
+	gasPrice, err := s.gasStrategy.bumpedGasPrice(tx.GasPrice(), suggested)
+	if err != nil {
+		logx.Errorf("unable to replace rollup tx %s, err: %v", pendingTx.L1TxHash, err)
+		return nil
+	}
+	newTx, err := s.signTx(ethTypes.NewTransaction(tx.Nonce(), *tx.To(), tx.Value(), tx.Gas(), gasPrice, tx.Data()))
+	if err != nil {
+		return err
+	}
+	// The replacement is recorded before it is sent, so that it is followed even if the sender stops
+	// right after sending it. The unsent hash is harmless since all the hashes are followed.
+	replacedTxHash := pendingTx.L1TxHash
+	err = s.l1RollupTxModel.ReplaceL1RollupTx(pendingTx, newTx.Hash().Hex(), gasPrice.String())
+	if err != nil {
+		return fmt.Errorf("failed to record replacement of rollup tx %s, err: %v", replacedTxHash, err)
+	}
+	err = s.cli.SendTransaction(context.Background(), newTx)
+	if err != nil {
+		return fmt.Errorf("failed to send replacement of rollup tx %s, err: %v", replacedTxHash, err)
+	}
+	logx.Infof("rollup tx %s is replaced by %s with gas price %s", replacedTxHash, pendingTx.L1TxHash, gasPrice)
+	return nil
+}
+
+func (s *Sender) signTx(tx *ethTypes.Transaction) (*ethTypes.Transaction, error) {
+	return ethTypes.SignTx(tx, ethTypes.LatestSignerForChainID(s.authCli.ChainId), s.authCli.PrivateKey)
+}
+
+func (s *Sender) signAndSendTx(tx *ethTypes.Transaction) (*ethTypes.Transaction, error) {
+	signedTx, err := s.signTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	err = s.cli.SendTransaction(context.Background(), signedTx)
+	if err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sender
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sconfig "github.com/bnb-chain/zkbnb/service/sender/config"
+)
+
+func TestGasStrategy(t *testing.T) {
+	var c sconfig.Config
+	c.ChainConfig.GasLimit = 1000000
+	c.ChainConfig.MaxGasPrice = 10
+	g := newGasStrategy(c)
+
+	// The suggested gas price is capped.
+	assert.Equal(t, big.NewInt(5e9), g.gasPrice(big.NewInt(5e9)))
+	assert.Equal(t, big.NewInt(10e9), g.gasPrice(big.NewInt(20e9)))
+
+	// The gas price is bumped by the percentage, or to the suggested gas price if it is higher.
+	gasPrice, err := g.bumpedGasPrice(big.NewInt(5e9), big.NewInt(1e9))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(6e9), gasPrice)
+	gasPrice, err = g.bumpedGasPrice(big.NewInt(5e9), big.NewInt(7e9))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(7e9), gasPrice)
+	gasPrice, err = g.bumpedGasPrice(big.NewInt(9e9), big.NewInt(1e9))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(10e9), gasPrice)
+	_, err = g.bumpedGasPrice(big.NewInt(10e9), big.NewInt(1e9))
+	assert.Equal(t, errMaxGasPriceReached, err)
+
+	// The estimated gas is increased by the margin under the gas limit.
+	gasLimit, err := g.gasLimit(500000)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(600000), gasLimit)
+	gasLimit, err = g.gasLimit(900000)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000000), gasLimit)
+	_, err = g.gasLimit(1100000)
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright © 2021 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sender
+
+import (
+	"math/big"
+
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/zeromicro/go-zero/core/metric"
+
+	"github.com/bnb-chain/zkbnb/dao/l1rolluptx"
+)
+
+const metricNamespace = "zkbnb"
+
+var (
+	pendingTxsMetric = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "sender",
+		Name:      "pending_txs",
+		Help:      "Number of rollup txs which are sent to l1 and not handled yet.",
+		Labels:    []string{"tx_type"},
+	})
+	handledHeightMetric = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "sender",
+		Name:      "handled_height",
+		Help:      "Height of the latest block in the handled rollup txs.",
+		Labels:    []string{"tx_type"},
+	})
+	sentTxsMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "sender",
+		Name:      "sent_txs_total",
+		Help:      "Number of rollup txs which are sent to l1.",
+		Labels:    []string{"tx_type"},
+	})
+	failedTxsMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "sender",
+		Name:      "failed_txs_total",
+		Help:      "Number of rollup txs which fail on l1.",
+		Labels:    []string{"tx_type"},
+	})
+	gasUsedMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "sender",
+		Name:      "gas_used_total",
+		Help:      "Gas used by the rollup txs which are finalized on l1.",
+		Labels:    []string{"tx_type"},
+	})
+	feeMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "sender",
+		Name:      "fee_gwei_total",
+		Help:      "Fee in gwei paid for the rollup txs which are finalized on l1, at the gas price of the latest sent tx.",
+		Labels:    []string{"tx_type"},
+	})
+)
+
+func rollupTxTypeName(txType uint8) string {
+	if txType == l1rolluptx.TxTypeCommit {
+		return "commit"
+	}
+	return "verify"
+}
+
+func updatePendingTxsMetric(pendingTxs []*l1rolluptx.L1RollupTx) {
+	counts := map[uint8]int{
+		l1rolluptx.TxTypeCommit:           0,
+		l1rolluptx.TxTypeVerifyAndExecute: 0,
+	}
+	for _, pendingTx := range pendingTxs {
+		counts[pendingTx.TxType]++
+	}
+	for txType, count := range counts {
+		pendingTxsMetric.Set(float64(count), rollupTxTypeName(txType))
+	}
+}
+
+func recordGasMetric(rollupTx *l1rolluptx.L1RollupTx, receipt *ethTypes.Receipt) {
+	txType := rollupTxTypeName(rollupTx.TxType)
+	gasUsedMetric.Add(float64(receipt.GasUsed), txType)
+	gasPrice, ok := new(big.Int).SetString(rollupTx.GasPrice, 10)
+	if !ok {
+		return
+	}
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	feeGwei, _ := new(big.Float).Quo(new(big.Float).SetInt(fee), big.NewFloat(1e9)).Float64()
+	feeMetric.Add(feeGwei, txType)
+}
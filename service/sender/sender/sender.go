@@ -24,6 +24,9 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/zeromicro/go-zero/core/logx"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -31,8 +34,10 @@ import (
 	"github.com/bnb-chain/zkbnb-eth-rpc/_rpc"
 	zkbnb "github.com/bnb-chain/zkbnb-eth-rpc/zkbnb/core/legend"
 	"github.com/bnb-chain/zkbnb/common/chain"
+	"github.com/bnb-chain/zkbnb/common/l1client"
 	"github.com/bnb-chain/zkbnb/common/prove"
 	"github.com/bnb-chain/zkbnb/dao/block"
+	"github.com/bnb-chain/zkbnb/dao/blockwitness"
 	"github.com/bnb-chain/zkbnb/dao/compressedblock"
 	"github.com/bnb-chain/zkbnb/dao/l1rolluptx"
 	"github.com/bnb-chain/zkbnb/dao/proof"
@@ -45,9 +50,18 @@ type Sender struct {
 	config sconfig.Config
 
 	// Client
-	cli           *_rpc.ProviderClient
-	authCli       *_rpc.AuthClient
-	zkbnbInstance *zkbnb.ZkBNB
+	cli          *l1client.Client
+	authCli      *_rpc.AuthClient
+	zkbnbAddress common.Address
+	gasStrategy  *gasStrategy
+
+	// Verifying keys indexed by block size.
+	verifyingKeys map[int]groth16.VerifyingKey
+	// Ids of the verified proofs indexed by block height, a proof is only verified when it's sent
+	// for the first time.
+	verifiedProofs map[int64]uint
+	// Hashes of the failed rollup txs which are reported.
+	failedTxs map[string]bool
 
 	// Data access objects
 	db                   *gorm.DB
@@ -56,6 +70,7 @@ type Sender struct {
 	l1RollupTxModel      l1rolluptx.L1RollupTxModel
 	sysConfigModel       sysconfig.SysConfigModel
 	proofModel           proof.ProofModel
+	blockWitnessModel    blockwitness.BlockWitnessModel
 }
 
 func NewSender(c sconfig.Config) *Sender {
@@ -71,6 +86,15 @@ func NewSender(c sconfig.Config) *Sender {
 		l1RollupTxModel:      l1rolluptx.NewL1RollupTxModel(db),
 		sysConfigModel:       sysconfig.NewSysConfigModel(db),
 		proofModel:           proof.NewProofModel(db),
+		blockWitnessModel:    blockwitness.NewBlockWitnessModel(db),
+		verifiedProofs:       make(map[int64]uint),
+		failedTxs:            make(map[string]bool),
+		gasStrategy:          newGasStrategy(c),
+	}
+
+	s.verifyingKeys, err = prove.LoadVerifyingKeys(c.KeyPath.ManifestPath, c.BlockConfig.OptionalBlockSizes, c.KeyPath.VerifyingKeyPath)
+	if err != nil {
+		panic(fmt.Sprintf("verifyingKey loading error: %v", err))
 	}
 
 	l1RPCEndpoint, err := s.sysConfigModel.GetSysConfigByName(c.ChainConfig.NetworkRPCSysConfigName)
@@ -86,7 +110,7 @@ func NewSender(c sconfig.Config) *Sender {
 		panic(err)
 	}
 
-	s.cli, err = _rpc.NewClient(l1RPCEndpoint.Value)
+	s.cli, err = l1client.NewClient(l1client.ParseEndpoints(l1RPCEndpoint.Value), false)
 	if err != nil {
 		panic(err)
 	}
@@ -94,23 +118,15 @@ func NewSender(c sconfig.Config) *Sender {
 	if err != nil {
 		panic(err)
 	}
-	s.authCli, err = _rpc.NewAuthClient(s.cli, c.ChainConfig.Sk, chainId)
-	if err != nil {
-		panic(err)
-	}
-	s.zkbnbInstance, err = zkbnb.LoadZkBNBInstance(s.cli, rollupAddress.Value)
+	s.authCli, err = _rpc.NewAuthClient(s.cli.Provider(), c.ChainConfig.Sk, chainId)
 	if err != nil {
 		panic(err)
 	}
+	s.zkbnbAddress = common.HexToAddress(rollupAddress.Value)
 	return s
 }
 
 func (s *Sender) CommitBlocks() (err error) {
-	var (
-		cli           = s.cli
-		authCli       = s.authCli
-		zkbnbInstance = s.zkbnbInstance
-	)
 	pendingTx, err := s.l1RollupTxModel.GetLatestPendingTx(l1rolluptx.TxTypeCommit)
 	if err != nil && err != types.DbErrNotFound {
 		return err
@@ -137,10 +153,6 @@ func (s *Sender) CommitBlocks() (err error) {
 	if len(blocks) == 0 {
 		return nil
 	}
-	pendingCommitBlocks, err := ConvertBlocksForCommitToCommitBlockInfos(blocks)
-	if err != nil {
-		return fmt.Errorf("failed to get commit block info, err: %v", err)
-	}
 	// get last block info
 	lastStoredBlockInfo := defaultBlockHeader()
 	if lastHandledTx != nil {
@@ -151,33 +163,33 @@ func (s *Sender) CommitBlocks() (err error) {
 		// construct last stored block header
 		lastStoredBlockInfo = chain.ConstructStoredBlockInfo(lastHandledBlockInfo)
 	}
-
-	gasPrice, err := s.cli.SuggestGasPrice(context.Background())
+	batch, err := s.chooseCommitBatch(lastStoredBlockInfo, blocks)
 	if err != nil {
-		logx.Errorf("failed to fetch gas price: %v", err)
 		return err
 	}
+	if batch == nil {
+		return nil
+	}
+	pendingCommitBlocks := batch.infos
+
 	// commit blocks on-chain
-	txHash, err := zkbnb.CommitBlocks(
-		cli, authCli,
-		zkbnbInstance,
-		lastStoredBlockInfo,
-		pendingCommitBlocks,
-		gasPrice,
-		s.config.ChainConfig.GasLimit)
+	tx, err := s.sendRollupCalldata("commitBlocks", batch.calldata)
 	if err != nil {
 		return fmt.Errorf("failed to send commit tx, errL %v", err)
 	}
 	newRollupTx := &l1rolluptx.L1RollupTx{
-		L1TxHash:      txHash,
+		L1TxHash:      tx.Hash().Hex(),
 		TxStatus:      l1rolluptx.StatusPending,
 		TxType:        l1rolluptx.TxTypeCommit,
 		L2BlockHeight: int64(pendingCommitBlocks[len(pendingCommitBlocks)-1].BlockNumber),
+		L1Nonce:       tx.Nonce(),
+		GasPrice:      tx.GasPrice().String(),
 	}
 	err = s.l1RollupTxModel.CreateL1RollupTx(newRollupTx)
 	if err != nil {
 		return fmt.Errorf("failed to create tx in database, err: %v", err)
 	}
+	sentTxsMetric.Inc(rollupTxTypeName(newRollupTx.TxType))
 	logx.Infof("new blocks have been committed(height): %v", newRollupTx.L2BlockHeight)
 	return nil
 }
@@ -186,10 +198,12 @@ func (s *Sender) UpdateSentTxs() (err error) {
 	pendingTxs, err := s.l1RollupTxModel.GetL1RollupTxsByStatus(l1rolluptx.StatusPending)
 	if err != nil {
 		if err == types.DbErrNotFound {
+			updatePendingTxsMetric(nil)
 			return nil
 		}
 		return fmt.Errorf("failed to get pending txs, err: %v", err)
 	}
+	updatePendingTxsMetric(pendingTxs)
 
 	latestL1Height, err := s.cli.GetHeight()
 	if err != nil {
@@ -198,29 +212,36 @@ func (s *Sender) UpdateSentTxs() (err error) {
 
 	var (
 		pendingUpdateRxs         []*l1rolluptx.L1RollupTx
+		pendingUpdateReceipts    []*ethTypes.Receipt
 		pendingUpdateProofStatus = make(map[int64]int)
+		failedTxHashes           []string
 	)
 	for _, pendingTx := range pendingTxs {
-		txHash := pendingTx.L1TxHash
-		receipt, err := s.cli.GetTransactionReceipt(txHash)
+		receipt, err := s.getRollupTxReceipt(pendingTx)
 		if err != nil {
-			logx.Errorf("query transaction receipt %s failed, err: %v", txHash, err)
-			if time.Now().After(pendingTx.UpdatedAt.Add(time.Duration(s.config.ChainConfig.MaxWaitingTime) * time.Second)) {
-				// No need to check the response, do best effort.
-				//nolint:errcheck
-				s.l1RollupTxModel.DeleteL1RollupTx(pendingTx)
-			}
+			logx.Errorf("query transaction receipt %s failed, err: %v", pendingTx.L1TxHash, err)
+			s.handleUnpackedTx(pendingTx)
 			continue
 		}
-		if receipt.Status == 0 {
-			// It is critical to have any failed transactions
-			panic(fmt.Sprintf("unexpected failed tx: %v", txHash))
-		}
-
+		txHash := pendingTx.L1TxHash
 		// not finalized yet
 		if latestL1Height < receipt.BlockNumber.Uint64()+s.config.ChainConfig.ConfirmBlocksCount {
 			continue
 		}
+		if receipt.Status == 0 {
+			// The tx fails if its blocks are reverted on L1 while it is pending. The monitor deletes the
+			// rollup txs of the reverted blocks when it syncs the BlocksRevert event, so that they are sent
+			// again from the last stored block. Any other failure is critical, the tx is kept and reported
+			// until it's resolved.
+			logx.Severef("failed rollup tx: %s, type: %d, height: %d", txHash, pendingTx.TxType, pendingTx.L2BlockHeight)
+			if !s.failedTxs[txHash] {
+				s.failedTxs[txHash] = true
+				failedTxsMetric.Inc(rollupTxTypeName(pendingTx.TxType))
+				recordGasMetric(pendingTx, receipt)
+			}
+			failedTxHashes = append(failedTxHashes, txHash)
+			continue
+		}
 		var validTx bool
 		for _, vlog := range receipt.Logs {
 			switch vlog.Topics[0].Hex() {
@@ -238,7 +259,7 @@ func (s *Sender) UpdateSentTxs() (err error) {
 				validTx = int64(event.BlockNumber) == pendingTx.L2BlockHeight
 				pendingUpdateProofStatus[int64(event.BlockNumber)] = proof.Confirmed
 			case zkbnbLogBlocksRevertSigHash.Hex():
-				// TODO revert
+				// the reverted blocks are reset by the monitor
 			default:
 			}
 		}
@@ -246,6 +267,7 @@ func (s *Sender) UpdateSentTxs() (err error) {
 		if validTx {
 			pendingTx.TxStatus = l1rolluptx.StatusHandled
 			pendingUpdateRxs = append(pendingUpdateRxs, pendingTx)
+			pendingUpdateReceipts = append(pendingUpdateReceipts, receipt)
 		}
 	}
 
@@ -263,15 +285,59 @@ func (s *Sender) UpdateSentTxs() (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to updte rollup txs, err:%v", err)
 	}
+	for i, handledTx := range pendingUpdateRxs {
+		recordGasMetric(handledTx, pendingUpdateReceipts[i])
+		handledHeightMetric.Set(float64(handledTx.L2BlockHeight), rollupTxTypeName(handledTx.TxType))
+	}
+	if len(failedTxHashes) > 0 {
+		return fmt.Errorf("rollup txs %v failed and their blocks are not reverted on l1", failedTxHashes)
+	}
 	return nil
 }
 
+// getRollupTxReceipt returns the receipt of whichever tx of the rollup tx lands, the rollup tx
+// follows the landed tx if it is a replaced one.
+func (s *Sender) getRollupTxReceipt(pendingTx *l1rolluptx.L1RollupTx) (receipt *ethTypes.Receipt, err error) {
+	for _, txHash := range pendingTx.TxHashes() {
+		receipt, err = s.cli.GetTransactionReceipt(txHash)
+		if err != nil {
+			continue
+		}
+		if txHash != pendingTx.L1TxHash {
+			logx.Infof("replaced rollup tx %s lands instead of %s", txHash, pendingTx.L1TxHash)
+			pendingTx.L1TxHash = txHash
+		}
+		return receipt, nil
+	}
+	return nil, err
+}
+
+// handleUnpackedTx replaces the rollup tx which is still pending in the tx pool at a higher gas
+// price, or deletes it if it is dropped, so that the blocks are sent again.
+func (s *Sender) handleUnpackedTx(pendingTx *l1rolluptx.L1RollupTx) {
+	for _, txHash := range pendingTx.TxHashes() {
+		tx, isPending, err := s.cli.GetTransactionByHash(txHash)
+		if err != nil {
+			continue
+		}
+		if !isPending {
+			// It is packed, the receipt will be available soon.
+			return
+		}
+		err = s.replaceRollupTx(pendingTx, tx)
+		if err != nil {
+			logx.Errorf("failed to replace rollup tx, err: %v", err)
+		}
+		return
+	}
+	if time.Now().After(pendingTx.UpdatedAt.Add(time.Duration(s.config.ChainConfig.MaxWaitingTime) * time.Second)) {
+		// No need to check the response, do best effort.
+		//nolint:errcheck
+		s.l1RollupTxModel.DeleteL1RollupTx(pendingTx)
+	}
+}
+
 func (s *Sender) VerifyAndExecuteBlocks() (err error) {
-	var (
-		cli           = s.cli
-		authCli       = s.authCli
-		zkbnbInstance = s.zkbnbInstance
-	)
 	pendingTx, err := s.l1RollupTxModel.GetLatestPendingTx(l1rolluptx.TxTypeVerifyAndExecute)
 	if err != nil && err != types.DbErrNotFound {
 		return err
@@ -290,10 +356,9 @@ func (s *Sender) VerifyAndExecuteBlocks() (err error) {
 	if lastHandledTx != nil {
 		start = lastHandledTx.L2BlockHeight + 1
 	}
-	blocks, err := s.blockModel.GetCommittedBlocksBetween(start,
-		start+int64(s.config.ChainConfig.MaxBlockCount))
-	if err != nil && err != types.DbErrNotFound {
-		return fmt.Errorf("unable to get blocks to prove, err: %v", err)
+	blocks, proofs, err := s.getBlockProofs(start)
+	if err != nil {
+		return err
 	}
 	if len(blocks) == 0 {
 		return nil
@@ -302,47 +367,118 @@ func (s *Sender) VerifyAndExecuteBlocks() (err error) {
 	if err != nil {
 		return fmt.Errorf("unable to convert blocks to commit block infos: %v", err)
 	}
-
-	blockProofs, err := s.proofModel.GetProofsBetween(start, start+int64(len(blocks))-1)
-	if err != nil {
-		return fmt.Errorf("unable to get proofs, err: %v", err)
-	}
-	if len(blockProofs) != len(blocks) {
-		return errors.New("related proofs not ready")
-	}
-	var proofs []*big.Int
-	for _, bProof := range blockProofs {
-		var proofInfo *prove.FormattedProof
-		err = json.Unmarshal([]byte(bProof.ProofInfo), &proofInfo)
-		if err != nil {
-			return err
-		}
-		proofs = append(proofs, proofInfo.A[:]...)
-		proofs = append(proofs, proofInfo.B[0][0], proofInfo.B[0][1])
-		proofs = append(proofs, proofInfo.B[1][0], proofInfo.B[1][1])
-		proofs = append(proofs, proofInfo.C[:]...)
-	}
-	gasPrice, err := s.cli.SuggestGasPrice(context.Background())
-	if err != nil {
-		return err
-	}
 	// Verify blocks on-chain
-	txHash, err := zkbnb.VerifyAndExecuteBlocks(cli, authCli, zkbnbInstance,
-		pendingVerifyAndExecuteBlocks, proofs, gasPrice, s.config.ChainConfig.GasLimit)
+	tx, err := s.sendRollupTx("verifyAndExecuteBlocks", pendingVerifyAndExecuteBlocks, proofs)
 	if err != nil {
 		return fmt.Errorf("failed to send verify tx: %v", err)
 	}
 
 	newRollupTx := &l1rolluptx.L1RollupTx{
-		L1TxHash:      txHash,
+		L1TxHash:      tx.Hash().Hex(),
 		TxStatus:      l1rolluptx.StatusPending,
 		TxType:        l1rolluptx.TxTypeVerifyAndExecute,
 		L2BlockHeight: int64(pendingVerifyAndExecuteBlocks[len(pendingVerifyAndExecuteBlocks)-1].BlockHeader.BlockNumber),
+		L1Nonce:       tx.Nonce(),
+		GasPrice:      tx.GasPrice().String(),
 	}
 	err = s.l1RollupTxModel.CreateL1RollupTx(newRollupTx)
 	if err != nil {
 		return fmt.Errorf(fmt.Sprintf("failed to create rollup tx in db %v", err))
 	}
+	sentTxsMetric.Inc(rollupTxTypeName(newRollupTx.TxType))
 	logx.Infof("new blocks have been verified and executed(height): %d", newRollupTx.L2BlockHeight)
 	return nil
 }
+
+// getBlockProofs returns the committed blocks from start and their proofs, each proof is
+// verified locally before it is submitted for the first time.
+func (s *Sender) getBlockProofs(start int64) ([]*block.Block, []*big.Int, error) {
+	blocks, err := s.blockModel.GetCommittedBlocksBetween(start,
+		start+int64(s.config.ChainConfig.MaxBlockCount))
+	if err != nil && err != types.DbErrNotFound {
+		return nil, nil, fmt.Errorf("unable to get blocks to prove, err: %v", err)
+	}
+	if len(blocks) == 0 {
+		return nil, nil, nil
+	}
+
+	blockProofs, err := s.proofModel.GetProofsBetween(start, start+int64(len(blocks))-1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get proofs, err: %v", err)
+	}
+	if len(blockProofs) != len(blocks) {
+		return nil, nil, errors.New("related proofs not ready")
+	}
+	lastBlock, err := s.blockModel.GetBlockByHeightWithoutTx(start - 1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get block %d, err: %v", start-1, err)
+	}
+	oldStateRoot := lastBlock.StateRoot
+	for height := range s.verifiedProofs {
+		if height < start {
+			delete(s.verifiedProofs, height)
+		}
+	}
+	var proofs []*big.Int
+	for i, bProof := range blockProofs {
+		proofInfo, err := s.verifyProof(blocks[i], oldStateRoot, bProof)
+		if err != nil {
+			return nil, nil, err
+		}
+		oldStateRoot = blocks[i].StateRoot
+		proofs = appendProof(proofs, proofInfo)
+	}
+	return blocks, proofs, nil
+}
+
+func appendProof(proofs []*big.Int, proofInfo *prove.FormattedProof) []*big.Int {
+	proofs = append(proofs, proofInfo.A[:]...)
+	proofs = append(proofs, proofInfo.B[0][0], proofInfo.B[0][1])
+	proofs = append(proofs, proofInfo.B[1][0], proofInfo.B[1][1])
+	proofs = append(proofs, proofInfo.C[:]...)
+	return proofs
+}
+
+// verifyProof checks the proof of the block locally before it is submitted to L1, the invalid
+// proof is quarantined and the witness of the block is rescheduled to be proved again.
+func (s *Sender) verifyProof(b *block.Block, oldStateRoot string, blockProof *proof.Proof) (*prove.FormattedProof, error) {
+	if blockProof.BlockNumber != b.BlockHeight {
+		return nil, fmt.Errorf("proof of block %d is missing", b.BlockHeight)
+	}
+	verifyingKey, ok := s.verifyingKeys[int(b.BlockSize)]
+	if !ok {
+		return nil, fmt.Errorf("can't find vk for block size %d", b.BlockSize)
+	}
+
+	var proofInfo *prove.FormattedProof
+	err := json.Unmarshal([]byte(blockProof.ProofInfo), &proofInfo)
+	if err == nil {
+		if s.verifiedProofs[b.BlockHeight] == blockProof.ID {
+			return proofInfo, nil
+		}
+		err = prove.VerifyProof(proofInfo, verifyingKey,
+			common.FromHex(oldStateRoot), common.FromHex(b.StateRoot), common.FromHex(b.BlockCommitment))
+	}
+	if err == nil {
+		s.verifiedProofs[b.BlockHeight] = blockProof.ID
+		return proofInfo, nil
+	}
+
+	logx.Severef("invalid proof of block %d, err: %v", b.BlockHeight, err)
+	res := s.db.Transaction(func(tx *gorm.DB) error {
+		err := s.proofModel.QuarantineProofInTransact(tx, blockProof)
+		if err != nil {
+			return err
+		}
+		return s.blockWitnessModel.RescheduleBlockWitnessInTransact(tx, b.BlockHeight)
+	})
+	if res != nil {
+		return nil, fmt.Errorf("failed to quarantine proof of block %d, err: %v", b.BlockHeight, res)
+	}
+	return nil, fmt.Errorf("proof of block %d is invalid and quarantined, err: %v", b.BlockHeight, err)
+}
+
+// CheckL1Endpoints checks the health of the l1 rpc endpoints.
+func (s *Sender) CheckL1Endpoints() {
+	s.cli.CheckHealth()
+}
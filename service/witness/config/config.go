@@ -2,7 +2,9 @@ package config
 
 import (
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/prometheus"
 
+	"github.com/bnb-chain/zkbnb/common/storage"
 	"github.com/bnb-chain/zkbnb/tree"
 )
 
@@ -17,5 +19,15 @@ type Config struct {
 		//nolint:staticcheck
 		RedisDBOption tree.RedisDBOption `json:",optional"`
 	}
-	LogConf logx.LogConf
+	LogConf    logx.LogConf
+	Prometheus prometheus.Config `json:",optional"`
+	// Number of blocks whose witnesses are generated and inserted together, 10 by default. The trees
+	// are still committed once per block, only the encoding and saving of the witnesses overlap.
+	BatchSize int `json:",optional"`
+	// Number of goroutines serializing the witnesses, the number of cpus by default.
+	Parallelism int `json:",optional"`
+	// Blob storage of the witnesses, the witnesses are in Postgres if it is not set.
+	WitnessStorage storage.Config `json:",optional"`
+	// Evaluate the circuit constraints on each witness after it is generated.
+	CheckConstraints bool `json:",optional"`
 }
@@ -1,10 +1,18 @@
 package witness
 
 import (
+	"fmt"
+
 	"github.com/robfig/cron/v3"
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/proc"
+	"github.com/zeromicro/go-zero/core/prometheus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/zkbnb/common/prove"
+	"github.com/bnb-chain/zkbnb/dao/blockwitness"
 
 	"github.com/bnb-chain/zkbnb/service/witness/config"
 	"github.com/bnb-chain/zkbnb/service/witness/witness"
@@ -22,6 +30,7 @@ func Run(configFile string) error {
 	proc.AddShutdownListener(func() {
 		logx.Close()
 	})
+	prometheus.StartAgent(c.Prometheus)
 
 	cronJob := cron.New(cron.WithChain(
 		cron.SkipIfStillRunning(cron.DiscardLogger),
@@ -42,3 +51,31 @@ func Run(configFile string) error {
 	logx.Info("witness cronjob is starting......")
 	select {}
 }
+
+// Check evaluates the circuit constraints on the saved witness of the block without proving it.
+func Check(configFile string, height int64) error {
+	var c config.Config
+	conf.MustLoad(configFile, &c)
+	db, err := gorm.Open(postgres.Open(c.Postgres.DataSource))
+	if err != nil {
+		return fmt.Errorf("gorm connect db error, err: %v", err)
+	}
+	witnessStore, err := prove.NewWitnessStore(c.WitnessStorage)
+	if err != nil {
+		return fmt.Errorf("witness storage init error, err: %v", err)
+	}
+	blockWitness, err := blockwitness.NewBlockWitnessModel(db).GetBlockWitnessByHeight(height)
+	if err != nil {
+		return fmt.Errorf("failed to get witness of block %d, err: %v", height, err)
+	}
+	b, err := witnessStore.Load(blockWitness)
+	if err != nil {
+		return fmt.Errorf("failed to load witness of block %d, err: %v", height, err)
+	}
+	err = prove.CheckBlockWitness(b)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("witness of block %d satisfies the constraints of %d txs\n", height, len(b.Txs))
+	return nil
+}
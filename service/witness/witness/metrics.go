@@ -0,0 +1,61 @@
+package witness
+
+import (
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+const metricNamespace = "zkbnb"
+
+var (
+	witnessHeightMetric = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "witness",
+		Name:      "height",
+		Help:      "Height of the latest block witness.",
+	})
+	committedHeightMetric = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "witness",
+		Name:      "committed_height",
+		Help:      "Height of the latest block created by the committer.",
+	})
+	witnessLagMetric = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "witness",
+		Name:      "lag_blocks",
+		Help:      "Number of blocks created by the committer which have no witness yet.",
+	})
+	witnessBlocksMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "witness",
+		Name:      "blocks_total",
+		Help:      "Number of blocks whose witnesses are generated.",
+	})
+	witnessTxsMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "witness",
+		Name:      "txs_total",
+		Help:      "Number of txs whose witnesses are generated.",
+	})
+	witnessBatchDurationMetric = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "witness",
+		Name:      "batch_duration_ms",
+		Help:      "Duration of generating the witnesses of a batch of blocks in milliseconds.",
+		Buckets:   []float64{100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000},
+	})
+	witnessBlockDurationMetric = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "witness",
+		Name:      "block_duration_ms",
+		Help:      "Duration of constructing the witness of a block on the trees in milliseconds.",
+		Labels:    []string{"block_size"},
+		Buckets:   []float64{10, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+	})
+	witnessCheckFailuresMetric = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Subsystem: "witness",
+		Name:      "constraint_check_failures_total",
+		Help:      "Number of block witnesses which don't satisfy the circuit constraints.",
+	})
+)
@@ -1,9 +1,11 @@
 package witness
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -19,22 +21,22 @@ import (
 	"github.com/bnb-chain/zkbnb/dao/blockwitness"
 	"github.com/bnb-chain/zkbnb/dao/liquidity"
 	"github.com/bnb-chain/zkbnb/dao/nft"
-	"github.com/bnb-chain/zkbnb/dao/proof"
 	"github.com/bnb-chain/zkbnb/service/witness/config"
 	"github.com/bnb-chain/zkbnb/tree"
 	"github.com/bnb-chain/zkbnb/types"
 )
 
 const (
-	UnprovedBlockWitnessTimeout = 10 * time.Minute
-
 	BlockProcessDelta = 10
 )
 
 type Witness struct {
 	// config
-	config config.Config
-	helper *utils.WitnessHelper
+	config       config.Config
+	helper       *utils.WitnessHelper
+	witnessStore *utils.WitnessStore
+	batchSize    int
+	parallelism  int
 
 	// Trees
 	treeCtx       *tree.Context
@@ -49,7 +51,6 @@ type Witness struct {
 	accountHistoryModel   account.AccountHistoryModel
 	liquidityHistoryModel liquidity.LiquidityHistoryModel
 	nftHistoryModel       nft.L2NftHistoryModel
-	proofModel            proof.ProofModel
 	blockWitnessModel     blockwitness.BlockWitnessModel
 }
 
@@ -62,13 +63,24 @@ func NewWitness(c config.Config) (*Witness, error) {
 
 	w := &Witness{
 		config:                c,
+		batchSize:             c.BatchSize,
+		parallelism:           c.Parallelism,
 		blockModel:            block.NewBlockModel(db),
 		blockWitnessModel:     blockwitness.NewBlockWitnessModel(db),
 		accountModel:          account.NewAccountModel(db),
 		accountHistoryModel:   account.NewAccountHistoryModel(db),
 		liquidityHistoryModel: liquidity.NewLiquidityHistoryModel(db),
 		nftHistoryModel:       nft.NewL2NftHistoryModel(db),
-		proofModel:            proof.NewProofModel(db),
+	}
+	w.witnessStore, err = utils.NewWitnessStore(c.WitnessStorage)
+	if err != nil {
+		return nil, fmt.Errorf("witness storage init error: %v", err)
+	}
+	if w.batchSize <= 0 {
+		w.batchSize = BlockProcessDelta
+	}
+	if w.parallelism <= 0 {
+		w.parallelism = runtime.NumCPU()
 	}
 	err = w.initState()
 	return w, err
@@ -130,8 +142,13 @@ func (w *Witness) GenerateBlockWitness() (err error) {
 	if err != nil && err != types.DbErrNotFound {
 		return err
 	}
+	w.updateLagMetrics(latestWitnessHeight)
+	err = w.rollbackToWitnessHeight(latestWitnessHeight)
+	if err != nil {
+		return err
+	}
 	// get next batch of blocks
-	blocks, err := w.blockModel.GetBlocksBetween(latestWitnessHeight+1, latestWitnessHeight+BlockProcessDelta)
+	blocks, err := w.blockModel.GetBlocksBetween(latestWitnessHeight+1, latestWitnessHeight+int64(w.batchSize))
 	if err != nil {
 		if err != types.DbErrNotFound {
 			return err
@@ -144,73 +161,143 @@ func (w *Witness) GenerateBlockWitness() (err error) {
 		return err
 	}
 
-	// scan each block
+	startedAt := time.Now()
+	assetTreesCount := len(w.assetTrees)
+	witnesses, err := w.witnessBlocks(blocks, latestVerifiedBlockNr, w.constructBlockWitness)
+	if err != nil {
+		return err
+	}
+	// Step3: insert witnesses into database
+	err = w.blockWitnessModel.CreateBlockWitnesses(witnesses)
+	if err != nil {
+		w.rollbackTrees(blocks[0].BlockHeight-1, assetTreesCount)
+		return fmt.Errorf("create unproved crypto block error, err: %v", err)
+	}
+
+	txsCount := 0
 	for _, block := range blocks {
+		txsCount += len(block.Txs)
+	}
+	latestWitnessHeight = blocks[len(blocks)-1].BlockHeight
+	witnessBlocksMetric.Add(float64(len(blocks)))
+	witnessTxsMetric.Add(float64(txsCount))
+	witnessBatchDurationMetric.Observe(time.Since(startedAt).Milliseconds())
+	w.updateLagMetrics(latestWitnessHeight)
+	logx.Infof("generated witnesses of blocks %d to %d in %s", blocks[0].BlockHeight, latestWitnessHeight, time.Since(startedAt))
+	return nil
+}
+
+// witnessBlocks constructs the witnesses on the trees one block after another, the witnesses are
+// encoded and saved to the witness storage concurrently while the next block is being constructed.
+// The trees are committed once per block with the changes of that block, so that every tree version
+// holds the state at that block height, and the trees are rolled back to the height before the batch
+// on any failure.
+func (w *Witness) witnessBlocks(blocks []*block.Block, latestVerifiedBlockNr int64,
+	construct func(*block.Block, int64) (*cryptoBlock.Block, error)) ([]*blockwitness.BlockWitness, error) {
+	assetTreesCount := len(w.assetTrees)
+	var (
+		witnesses = make([]*blockwitness.BlockWitness, len(blocks))
+		errs      = make([]error, len(blocks))
+		sem       = make(chan struct{}, w.parallelism)
+		wg        sync.WaitGroup
+	)
+	for i, block := range blocks {
 		// Step1: construct witness
-		blockWitness, err := w.constructBlockWitness(block, latestVerifiedBlockNr)
+		blockStartedAt := time.Now()
+		cBlock, err := construct(block, latestVerifiedBlockNr)
 		if err != nil {
-			return fmt.Errorf("failed to construct block witness, err: %v", err)
+			wg.Wait()
+			w.rollbackTrees(blocks[0].BlockHeight-1, assetTreesCount)
+			return nil, fmt.Errorf("failed to construct block witness, err: %v", err)
 		}
 		// Step2: commit trees for witness
 		err = tree.CommitTrees(uint64(latestVerifiedBlockNr), w.accountTree, &w.assetTrees, w.liquidityTree, w.nftTree)
 		if err != nil {
-			return fmt.Errorf("unable to commit trees after txs is executed, error: %v", err)
+			wg.Wait()
+			w.rollbackTrees(blocks[0].BlockHeight-1, assetTreesCount)
+			return nil, fmt.Errorf("unable to commit trees after txs is executed, error: %v", err)
 		}
-		// Step3: insert witness into database
-		err = w.blockWitnessModel.CreateBlockWitness(blockWitness)
-		if err != nil {
-			// rollback trees
-			rollBackErr := tree.RollBackTrees(uint64(block.BlockHeight)-1, w.accountTree, &w.assetTrees, w.liquidityTree, w.nftTree)
-			if rollBackErr != nil {
-				logx.Errorf("unable to rollback trees %v", rollBackErr)
+		witnessBlockDurationMetric.Observe(time.Since(blockStartedAt).Milliseconds(), strconv.Itoa(int(block.BlockSize)))
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if w.config.CheckConstraints {
+				w.checkBlockWitness(cBlock)
 			}
-			return fmt.Errorf("create unproved crypto block error, err: %v", err)
+			witnesses[i], errs[i] = w.saveBlockWitness(cBlock)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			w.rollbackTrees(blocks[0].BlockHeight-1, assetTreesCount)
+			return nil, fmt.Errorf("failed to save block witness, err: %v", err)
 		}
 	}
-	return nil
+	return witnesses, nil
 }
 
-func (w *Witness) RescheduleBlockWitness() {
-	latestConfirmedProof, err := w.proofModel.GetLatestConfirmedProof()
-	if err != nil && err != types.DbErrNotFound {
-		logx.Errorf("failed to get latest confirmed proof, err: %v", err)
-		return
+// rollbackToWitnessHeight rolls the trees back to the latest witness, the witnesses above it are deleted
+// when the blocks are reverted on L1, and they are generated again from the stored blocks.
+func (w *Witness) rollbackToWitnessHeight(latestWitnessHeight int64) error {
+	if w.accountTree.LatestVersion() <= smt.Version(latestWitnessHeight) {
+		return nil
 	}
-
-	var nextBlockNumber int64 = 1
-	if err != types.DbErrNotFound {
-		nextBlockNumber = latestConfirmedProof.BlockNumber + 1
+	logx.Infof("witnesses above %d are deleted, roll back the trees from %d", latestWitnessHeight, w.accountTree.LatestVersion())
+	err := tree.RollBackTrees(uint64(latestWitnessHeight), w.accountTree, &w.assetTrees, w.liquidityTree, w.nftTree)
+	if err != nil {
+		return fmt.Errorf("unable to rollback trees to %d, err: %v", latestWitnessHeight, err)
 	}
+	return nil
+}
 
-	nextBlockWitness, err := w.blockWitnessModel.GetBlockWitnessByHeight(nextBlockNumber)
+func (w *Witness) rollbackTrees(height int64, assetTreesCount int) {
+	err := tree.RollBackTrees(uint64(height), w.accountTree, &w.assetTrees, w.liquidityTree, w.nftTree)
 	if err != nil {
-		logx.Errorf("failed to get latest block witness, err: %v", err)
-		return
+		logx.Errorf("unable to rollback trees %v", err)
 	}
+	w.resetTrees(assetTreesCount)
+}
 
-	// skip if next block is not processed
-	if nextBlockWitness.Status == blockwitness.StatusPublished {
-		return
+// resetTrees drops the uncommitted changes of the trees and the asset trees created since then.
+func (w *Witness) resetTrees(assetTreesCount int) {
+	w.accountTree.Reset()
+	w.liquidityTree.Reset()
+	w.nftTree.Reset()
+	w.assetTrees = w.assetTrees[:assetTreesCount]
+	for _, assetTree := range w.assetTrees {
+		assetTree.Reset()
 	}
+}
 
-	// skip if the next block proof exists
-	// if the proof is not submitted and verified in L1, there should be another alerts
-	_, err = w.proofModel.GetProofByBlockHeight(nextBlockNumber)
-	if err == nil {
+func (w *Witness) updateLagMetrics(latestWitnessHeight int64) {
+	committedHeight, err := w.blockModel.GetCurrentBlockHeight()
+	if err != nil {
 		return
 	}
+	witnessHeightMetric.Set(float64(latestWitnessHeight))
+	committedHeightMetric.Set(float64(committedHeight))
+	witnessLagMetric.Set(float64(committedHeight - latestWitnessHeight))
+}
 
-	// update block status to Published if it's timeout
-	if time.Now().After(nextBlockWitness.UpdatedAt.Add(UnprovedBlockWitnessTimeout)) {
-		err := w.blockWitnessModel.UpdateBlockWitnessStatus(nextBlockWitness, blockwitness.StatusPublished)
-		if err != nil {
-			logx.Errorf("update unproved block status error, err: %v", err)
-			return
-		}
+// RescheduleBlockWitness gives the witnesses whose prover leases are expired back to the pool,
+// so that they can be picked up by any alive prover worker regardless of their heights.
+func (w *Witness) RescheduleBlockWitness() {
+	count, err := w.blockWitnessModel.ReclaimExpiredBlockWitnessLeases(time.Now())
+	if err != nil {
+		logx.Errorf("reclaim expired block witness leases error, err: %v", err)
+		return
+	}
+	if count > 0 {
+		logx.Infof("reclaimed %d block witnesses with expired leases", count)
 	}
 }
 
-func (w *Witness) constructBlockWitness(block *block.Block, latestVerifiedBlockNr int64) (*blockwitness.BlockWitness, error) {
+func (w *Witness) constructBlockWitness(block *block.Block, latestVerifiedBlockNr int64) (*cryptoBlock.Block, error) {
 	var oldStateRoot, newStateRoot []byte
 	txsWitness := make([]*utils.TxWitness, 0, block.BlockSize)
 	// scan each transaction
@@ -238,22 +325,35 @@ func (w *Witness) constructBlockWitness(block *block.Block, latestVerifiedBlockN
 		return nil, errors.New("state root doesn't match")
 	}
 
-	b := &cryptoBlock.Block{
+	return &cryptoBlock.Block{
 		BlockNumber:     block.BlockHeight,
 		CreatedAt:       block.CreatedAt.UnixMilli(),
 		OldStateRoot:    oldStateRoot,
 		NewStateRoot:    newStateRoot,
 		BlockCommitment: common.FromHex(block.BlockCommitment),
 		Txs:             txsWitness,
+	}, nil
+}
+
+func (w *Witness) saveBlockWitness(b *cryptoBlock.Block) (*blockwitness.BlockWitness, error) {
+	blockWitness := &blockwitness.BlockWitness{
+		Height:    b.BlockNumber,
+		BlockSize: int64(len(b.Txs)),
+		Status:    blockwitness.StatusPublished,
 	}
-	bz, err := json.Marshal(b)
+	err := w.witnessStore.Save(blockWitness, b)
 	if err != nil {
 		return nil, err
 	}
-	blockWitness := blockwitness.BlockWitness{
-		Height:      block.BlockHeight,
-		WitnessData: string(bz),
-		Status:      blockwitness.StatusPublished,
+	return blockWitness, nil
+}
+
+// checkBlockWitness reports the witness which doesn't satisfy the circuit, the witness is still saved
+// so that the pipeline is not blocked, and the failure is found long before the proof fails.
+func (w *Witness) checkBlockWitness(b *cryptoBlock.Block) {
+	err := utils.CheckBlockWitness(b)
+	if err != nil {
+		witnessCheckFailuresMetric.Inc()
+		logx.Severef("witness of block %d failed the constraint check, %v", b.BlockNumber, err)
 	}
-	return &blockWitness, nil
 }
@@ -0,0 +1,123 @@
+package witness
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cryptoBlock "github.com/bnb-chain/zkbnb-crypto/legend/circuit/bn254/block"
+	smt "github.com/bnb-chain/zkbnb-smt"
+	"github.com/bnb-chain/zkbnb/common/prove"
+	"github.com/bnb-chain/zkbnb/common/storage"
+	"github.com/bnb-chain/zkbnb/dao/block"
+	"github.com/bnb-chain/zkbnb/tree"
+)
+
+func newTestWitness(t *testing.T) *Witness {
+	newTree := func() smt.SparseMerkleTree {
+		memTree, err := tree.NewMemAccountAssetTree()
+		assert.NoError(t, err)
+		return memTree
+	}
+	witnessStore, err := prove.NewWitnessStore(storage.Config{})
+	assert.NoError(t, err)
+	return &Witness{
+		witnessStore:  witnessStore,
+		parallelism:   2,
+		accountTree:   newTree(),
+		assetTrees:    []smt.SparseMerkleTree{newTree()},
+		liquidityTree: newTree(),
+		nftTree:       newTree(),
+	}
+}
+
+func newTestBlocks(from, to int64) []*block.Block {
+	blocks := make([]*block.Block, 0, to-from+1)
+	for height := from; height <= to; height++ {
+		blocks = append(blocks, &block.Block{BlockHeight: height, BlockSize: 1})
+	}
+	return blocks
+}
+
+// constructTestBlock changes the account tree in every block, and records the root after the block.
+func constructTestBlock(t *testing.T, w *Witness, roots map[int64][]byte, failedHeight int64) func(*block.Block, int64) (*cryptoBlock.Block, error) {
+	return func(b *block.Block, _ int64) (*cryptoBlock.Block, error) {
+		if b.BlockHeight == failedHeight {
+			// The failed block leaves uncommitted changes behind.
+			assert.NoError(t, w.accountTree.Set(uint64(b.BlockHeight), tree.NilAccountAssetNodeHash))
+			return nil, errors.New("construct error")
+		}
+		leaf, err := tree.AssetToNode(strconv.FormatInt(b.BlockHeight*100, 10), "0", "0")
+		assert.NoError(t, err)
+		assert.NoError(t, w.accountTree.Set(uint64(b.BlockHeight), leaf))
+		roots[b.BlockHeight] = w.accountTree.Root()
+		return &cryptoBlock.Block{BlockNumber: b.BlockHeight}, nil
+	}
+}
+
+func TestWitnessBlocks(t *testing.T) {
+	w := newTestWitness(t)
+	roots := make(map[int64][]byte)
+
+	witnesses, err := w.witnessBlocks(newTestBlocks(1, 5), 0, constructTestBlock(t, w, roots, 0))
+	assert.NoError(t, err)
+	assert.Len(t, witnesses, 5)
+	for i, witness := range witnesses {
+		assert.Equal(t, int64(i+1), witness.Height)
+		b, err := w.witnessStore.Load(witness)
+		assert.NoError(t, err)
+		assert.Equal(t, witness.Height, b.BlockNumber)
+	}
+	// Every block is committed as its own version.
+	assert.Equal(t, smt.Version(5), w.accountTree.LatestVersion())
+	assert.Equal(t, roots[5], w.accountTree.Root())
+
+	// Any height in the batch is a valid rollback target.
+	assert.NoError(t, tree.RollBackTrees(3, w.accountTree, &w.assetTrees, w.liquidityTree, w.nftTree))
+	assert.Equal(t, roots[3], w.accountTree.Root())
+}
+
+func TestWitnessBlocksFailure(t *testing.T) {
+	w := newTestWitness(t)
+	roots := make(map[int64][]byte)
+
+	_, err := w.witnessBlocks(newTestBlocks(1, 2), 0, constructTestBlock(t, w, roots, 0))
+	assert.NoError(t, err)
+
+	// The trees are rolled back to the height before the batch if a block in the middle fails.
+	_, err = w.witnessBlocks(newTestBlocks(3, 6), 0, constructTestBlock(t, w, roots, 5))
+	assert.Error(t, err)
+	assert.Equal(t, smt.Version(2), w.accountTree.LatestVersion())
+	assert.Equal(t, roots[2], w.accountTree.Root())
+
+	// The batch is witnessed again from the same height.
+	witnesses, err := w.witnessBlocks(newTestBlocks(3, 6), 0, constructTestBlock(t, w, roots, 0))
+	assert.NoError(t, err)
+	assert.Len(t, witnesses, 4)
+	assert.Equal(t, smt.Version(6), w.accountTree.LatestVersion())
+	assert.Equal(t, roots[6], w.accountTree.Root())
+}
+
+func TestRollbackToWitnessHeight(t *testing.T) {
+	w := newTestWitness(t)
+	roots := make(map[int64][]byte)
+
+	_, err := w.witnessBlocks(newTestBlocks(1, 5), 0, constructTestBlock(t, w, roots, 0))
+	assert.NoError(t, err)
+	assert.NoError(t, w.rollbackToWitnessHeight(5))
+	assert.Equal(t, smt.Version(5), w.accountTree.LatestVersion())
+
+	// The witnesses above 3 are deleted after the blocks are reverted on L1.
+	assert.NoError(t, w.rollbackToWitnessHeight(3))
+	assert.Equal(t, smt.Version(3), w.accountTree.LatestVersion())
+	assert.Equal(t, roots[3], w.accountTree.Root())
+
+	// The witnesses are generated again from the stored blocks.
+	witnesses, err := w.witnessBlocks(newTestBlocks(4, 5), 0, constructTestBlock(t, w, roots, 0))
+	assert.NoError(t, err)
+	assert.Len(t, witnesses, 2)
+	assert.Equal(t, smt.Version(5), w.accountTree.LatestVersion())
+	assert.Equal(t, roots[5], w.accountTree.Root())
+}
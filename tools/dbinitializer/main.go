@@ -29,6 +29,7 @@ import (
 
 	"github.com/bnb-chain/zkbnb/dao/account"
 	"github.com/bnb-chain/zkbnb/dao/asset"
+	"github.com/bnb-chain/zkbnb/dao/auditlog"
 	"github.com/bnb-chain/zkbnb/dao/block"
 	"github.com/bnb-chain/zkbnb/dao/blockwitness"
 	"github.com/bnb-chain/zkbnb/dao/compressedblock"
@@ -37,8 +38,11 @@ import (
 	"github.com/bnb-chain/zkbnb/dao/liquidity"
 	"github.com/bnb-chain/zkbnb/dao/mempool"
 	"github.com/bnb-chain/zkbnb/dao/nft"
+	"github.com/bnb-chain/zkbnb/dao/offer"
 	"github.com/bnb-chain/zkbnb/dao/priorityrequest"
 	"github.com/bnb-chain/zkbnb/dao/proof"
+	"github.com/bnb-chain/zkbnb/dao/proverworker"
+	"github.com/bnb-chain/zkbnb/dao/royalty"
 	"github.com/bnb-chain/zkbnb/dao/sysconfig"
 	"github.com/bnb-chain/zkbnb/dao/tx"
 	"github.com/bnb-chain/zkbnb/tree"
@@ -79,6 +83,10 @@ type dao struct {
 	liquidityHistoryModel liquidity.LiquidityHistoryModel
 	nftModel              nft.L2NftModel
 	nftHistoryModel       nft.L2NftHistoryModel
+	offerModel            offer.OfferModel
+	royaltyModel          royalty.RoyaltyModel
+	proverWorkerModel     proverworker.ProverWorkerModel
+	auditLogModel         auditlog.AuditLogModel
 }
 
 func Initialize(
@@ -96,7 +104,30 @@ func Initialize(
 	unmarshal, _ := json.Marshal(svrConf)
 	logx.Infof("init configs: %s", string(unmarshal))
 
-	dao := &dao{
+	dao := newDao(db)
+
+	dropTables(dao, bscTestNetworkRPC, localTestNetworkRPC)
+	initTable(dao, &svrConf, bscTestNetworkRPC, localTestNetworkRPC)
+
+	return nil
+}
+
+// Migrate upgrades the tables of an existing database to the current schema, the data is kept.
+func Migrate(dsn string) error {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return err
+	}
+	err = createTables(newDao(db))
+	if err != nil {
+		return err
+	}
+	logx.Info("tables are migrated")
+	return nil
+}
+
+func newDao(db *gorm.DB) *dao {
+	return &dao{
 		sysConfigModel:        sysconfig.NewSysConfigModel(db),
 		accountModel:          account.NewAccountModel(db),
 		accountHistoryModel:   account.NewAccountHistoryModel(db),
@@ -116,12 +147,11 @@ func Initialize(
 		liquidityHistoryModel: liquidity.NewLiquidityHistoryModel(db),
 		nftModel:              nft.NewL2NftModel(db),
 		nftHistoryModel:       nft.NewL2NftHistoryModel(db),
+		offerModel:            offer.NewOfferModel(db),
+		royaltyModel:          royalty.NewRoyaltyModel(db),
+		proverWorkerModel:     proverworker.NewProverWorkerModel(db),
+		auditLogModel:         auditlog.NewAuditLogModel(db),
 	}
-
-	dropTables(dao, bscTestNetworkRPC, localTestNetworkRPC)
-	initTable(dao, &svrConf, bscTestNetworkRPC, localTestNetworkRPC)
-
-	return nil
 }
 
 func initSysConfig(svrConf *contractAddr, bscTestNetworkRPC, localTestNetworkRPC string) []*sysconfig.SysConfig {
@@ -176,6 +206,12 @@ func initSysConfig(svrConf *contractAddr, bscTestNetworkRPC, localTestNetworkRPC
 			ValueType: "string",
 			Comment:   "Zns Price Oracle",
 		},
+		{
+			Name:      types.PausedTxTypes,
+			Value:     "[]",
+			ValueType: "[]int",
+			Comment:   "tx types which are not accepted by sendTx",
+		},
 	}
 }
 
@@ -214,28 +250,49 @@ func dropTables(
 	assert.Nil(nil, dao.liquidityHistoryModel.DropLiquidityHistoryTable())
 	assert.Nil(nil, dao.nftModel.DropL2NftTable())
 	assert.Nil(nil, dao.nftHistoryModel.DropL2NftHistoryTable())
+	assert.Nil(nil, dao.offerModel.DropOfferTable())
+	assert.Nil(nil, dao.royaltyModel.DropRoyaltyTable())
+	assert.Nil(nil, dao.proverWorkerModel.DropProverWorkerTable())
+	assert.Nil(nil, dao.auditLogModel.DropAuditLogTable())
+}
+
+// createTables creates the tables, the existing tables are upgraded to the current schema.
+func createTables(dao *dao) error {
+	for _, createTable := range []func() error{
+		dao.sysConfigModel.CreateSysConfigTable,
+		dao.accountModel.CreateAccountTable,
+		dao.accountHistoryModel.CreateAccountHistoryTable,
+		dao.assetModel.CreateAssetTable,
+		dao.mempoolModel.CreateMempoolTxTable,
+		dao.failTxModel.CreateFailTxTable,
+		dao.blockModel.CreateBlockTable,
+		dao.txModel.CreateTxTable,
+		dao.txDetailModel.CreateTxDetailTable,
+		dao.compressedBlockModel.CreateCompressedBlockTable,
+		dao.blockWitnessModel.CreateBlockWitnessTable,
+		dao.proofModel.CreateProofTable,
+		dao.l1SyncedBlockModel.CreateL1SyncedBlockTable,
+		dao.priorityRequestModel.CreatePriorityRequestTable,
+		dao.l1RollupTModel.CreateL1RollupTxTable,
+		dao.liquidityModel.CreateLiquidityTable,
+		dao.liquidityHistoryModel.CreateLiquidityHistoryTable,
+		dao.nftModel.CreateL2NftTable,
+		dao.nftHistoryModel.CreateL2NftHistoryTable,
+		dao.offerModel.CreateOfferTable,
+		dao.royaltyModel.CreateRoyaltyTable,
+		dao.proverWorkerModel.CreateProverWorkerTable,
+		dao.auditLogModel.CreateAuditLogTable,
+	} {
+		err := createTable()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func initTable(dao *dao, svrConf *contractAddr, bscTestNetworkRPC, localTestNetworkRPC string) {
-	assert.Nil(nil, dao.sysConfigModel.CreateSysConfigTable())
-	assert.Nil(nil, dao.accountModel.CreateAccountTable())
-	assert.Nil(nil, dao.accountHistoryModel.CreateAccountHistoryTable())
-	assert.Nil(nil, dao.assetModel.CreateAssetTable())
-	assert.Nil(nil, dao.mempoolModel.CreateMempoolTxTable())
-	assert.Nil(nil, dao.failTxModel.CreateFailTxTable())
-	assert.Nil(nil, dao.blockModel.CreateBlockTable())
-	assert.Nil(nil, dao.txModel.CreateTxTable())
-	assert.Nil(nil, dao.txDetailModel.CreateTxDetailTable())
-	assert.Nil(nil, dao.compressedBlockModel.CreateCompressedBlockTable())
-	assert.Nil(nil, dao.blockWitnessModel.CreateBlockWitnessTable())
-	assert.Nil(nil, dao.proofModel.CreateProofTable())
-	assert.Nil(nil, dao.l1SyncedBlockModel.CreateL1SyncedBlockTable())
-	assert.Nil(nil, dao.priorityRequestModel.CreatePriorityRequestTable())
-	assert.Nil(nil, dao.l1RollupTModel.CreateL1RollupTxTable())
-	assert.Nil(nil, dao.liquidityModel.CreateLiquidityTable())
-	assert.Nil(nil, dao.liquidityHistoryModel.CreateLiquidityHistoryTable())
-	assert.Nil(nil, dao.nftModel.CreateL2NftTable())
-	assert.Nil(nil, dao.nftHistoryModel.CreateL2NftHistoryTable())
+	assert.Nil(nil, createTables(dao))
 	rowsAffected, err := dao.assetModel.CreateAssets(initAssetsInfo())
 	if err != nil {
 		panic(err)
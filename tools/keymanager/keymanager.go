@@ -0,0 +1,90 @@
+package keymanager
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/conf"
+
+	"github.com/bnb-chain/zkbnb/common/prove"
+	"github.com/bnb-chain/zkbnb/service/prover/config"
+)
+
+// GenerateManifest writes the manifest of the keys configured for the prover, the keys are
+// checked against the verifier contract first if verifierPath is set.
+func GenerateManifest(configFile, output, verifierPath string) error {
+	var c config.Config
+	conf.MustLoad(configFile, &c)
+	blockKeys, err := loadBlockKeys(c)
+	if err != nil {
+		return err
+	}
+	err = prove.VerifyBlockKeys("", verifierPath, blockKeys)
+	if err != nil {
+		return err
+	}
+
+	manifest := &prove.KeyManifest{}
+	for _, keys := range blockKeys {
+		entry, err := prove.NewKeyManifestEntry(keys)
+		if err != nil {
+			return err
+		}
+		manifest.Keys = append(manifest.Keys, entry)
+	}
+	err = manifest.Save(output)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("key manifest of block sizes %v is written to %s\n", c.BlockConfig.OptionalBlockSizes, output)
+	return nil
+}
+
+// VerifyKeys checks the keys configured for the prover against the manifest and the verifier contract.
+func VerifyKeys(configFile, verifierPath string) error {
+	var c config.Config
+	conf.MustLoad(configFile, &c)
+	if c.KeyPath.ManifestPath == "" {
+		return errors.New("KeyPath.ManifestPath is not configured")
+	}
+	if verifierPath == "" {
+		verifierPath = c.KeyPath.VerifierPath
+	}
+	blockKeys, err := loadBlockKeys(c)
+	if err != nil {
+		return err
+	}
+	err = prove.VerifyBlockKeys(c.KeyPath.ManifestPath, verifierPath, blockKeys)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("keys of block sizes %v match the manifest\n", c.BlockConfig.OptionalBlockSizes)
+	return nil
+}
+
+func loadBlockKeys(c config.Config) ([]*prove.BlockKeys, error) {
+	blockSizes := c.BlockConfig.OptionalBlockSizes
+	if len(c.KeyPath.ProvingKeyPath) != len(blockSizes) || len(c.KeyPath.VerifyingKeyPath) != len(blockSizes) {
+		return nil, errors.New("keys do not match block sizes")
+	}
+	blockKeys := make([]*prove.BlockKeys, 0, len(blockSizes))
+	for i, blockSize := range blockSizes {
+		fmt.Printf("compile block size %d circuit\n", blockSize)
+		r1cs, err := prove.CompileBlockCircuit(blockSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile block size %d circuit, err: %v", blockSize, err)
+		}
+		verifyingKey, err := prove.LoadVerifyingKey(c.KeyPath.VerifyingKeyPath[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load verifying key %s, err: %v", c.KeyPath.VerifyingKeyPath[i], err)
+		}
+		blockKeys = append(blockKeys, &prove.BlockKeys{
+			BlockSize:        blockSize,
+			R1cs:             r1cs,
+			ProvingKeyPath:   c.KeyPath.ProvingKeyPath[i],
+			VerifyingKeyPath: c.KeyPath.VerifyingKeyPath[i],
+			VerifyingKey:     verifyingKey,
+		})
+	}
+	return blockKeys, nil
+}
@@ -0,0 +1,62 @@
+package witnessmigration
+
+import (
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/conf"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/zkbnb/common/prove"
+	"github.com/bnb-chain/zkbnb/dao/blockwitness"
+	"github.com/bnb-chain/zkbnb/service/witness/config"
+	"github.com/bnb-chain/zkbnb/types"
+)
+
+// MigrateWitnesses converts the json witnesses to the compressed encoding, and moves them to the
+// witness storage of the witness service config if it is set.
+func MigrateWitnesses(configFile string, batchSize int) error {
+	var c config.Config
+	conf.MustLoad(configFile, &c)
+	if batchSize <= 0 {
+		return fmt.Errorf("invalid batch size %d", batchSize)
+	}
+	db, err := gorm.Open(postgres.Open(c.Postgres.DataSource))
+	if err != nil {
+		return fmt.Errorf("gorm connect db error, err: %v", err)
+	}
+	witnessStore, err := prove.NewWitnessStore(c.WitnessStorage)
+	if err != nil {
+		return fmt.Errorf("witness storage init error, err: %v", err)
+	}
+	blockWitnessModel := blockwitness.NewBlockWitnessModel(db)
+
+	migrated := 0
+	for {
+		witnesses, err := blockWitnessModel.GetBlockWitnessesByEncoding(blockwitness.EncodingJson, batchSize)
+		if err != nil {
+			if err == types.DbErrNotFound {
+				break
+			}
+			return err
+		}
+		for _, witness := range witnesses {
+			b, err := witnessStore.Load(witness)
+			if err != nil {
+				return fmt.Errorf("failed to load witness of block %d, err: %v", witness.Height, err)
+			}
+			err = witnessStore.Save(witness, b)
+			if err != nil {
+				return fmt.Errorf("failed to save witness of block %d, err: %v", witness.Height, err)
+			}
+			err = blockWitnessModel.UpdateBlockWitnessData(witness)
+			if err != nil {
+				return fmt.Errorf("failed to update witness of block %d, err: %v", witness.Height, err)
+			}
+		}
+		migrated += len(witnesses)
+		fmt.Printf("migrated %d witnesses, latest height %d\n", migrated, witnesses[len(witnesses)-1].Height)
+	}
+	fmt.Printf("migration finished, %d witnesses are migrated\n", migrated)
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"strconv"
 
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
 	"github.com/zeromicro/go-zero/core/logx"
 
 	"github.com/bnb-chain/zkbnb-crypto/hash/bn254/zmimc"
@@ -55,7 +56,7 @@ func InitAccountTree(
 	accountAssetTrees = make([]bsmt.SparseMerkleTree, accountNums)
 	for index := int64(0); index < accountNums; index++ {
 		// create account assets tree
-		accountAssetTrees[index], err = bsmt.NewBASSparseMerkleTree(bsmt.NewHasher(zmimc.Hmimc),
+		accountAssetTrees[index], err = bsmt.NewBASSparseMerkleTree(newAssetTreeHasher(),
 			SetNamespace(ctx, accountAssetNamespace(index)), AssetTreeHeight, NilAccountAssetNodeHash,
 			opts...)
 		if err != nil {
@@ -261,13 +262,19 @@ func NewEmptyAccountAssetTree(
 	blockHeight uint64,
 ) (tree bsmt.SparseMerkleTree, err error) {
 	return bsmt.NewBASSparseMerkleTree(
-		bsmt.NewHasher(zmimc.Hmimc),
+		newAssetTreeHasher(),
 		SetNamespace(ctx, accountAssetNamespace(index)),
 		AssetTreeHeight, NilAccountAssetNodeHash,
 		ctx.Options(int64(blockHeight))...)
 }
 
 func NewMemAccountAssetTree() (tree bsmt.SparseMerkleTree, err error) {
-	return bsmt.NewBASSparseMerkleTree(bsmt.NewHasher(zmimc.Hmimc),
+	return bsmt.NewBASSparseMerkleTree(newAssetTreeHasher(),
 		memory.NewMemoryDB(), AssetTreeHeight, NilAccountAssetNodeHash)
 }
+
+// newAssetTreeHasher gives each asset tree its own hash state, so that the asset trees of different
+// accounts can be updated concurrently.
+func newAssetTreeHasher() *bsmt.Hasher {
+	return bsmt.NewHasher(mimc.NewMiMC())
+}
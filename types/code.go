@@ -40,6 +40,10 @@ var (
 	DbErrFailToCreateNftHistory       = errors.New("fail to create nft history")
 	DbErrFailToCreatePriorityRequest  = errors.New("fail to create priority request")
 	DbErrFailToUpdatePriorityRequest  = errors.New("fail to update priority request")
+	DbErrFailToDeletePriorityRequest  = errors.New("fail to delete priority request")
+	DbErrFailToCreateOffer            = errors.New("fail to create offer")
+	DbErrFailToCreateRoyalty          = errors.New("fail to create royalty")
+	DbErrFailToCreateAuditLog         = errors.New("fail to create audit log")
 
 	JsonErrUnmarshal = errors.New("json.Unmarshal err")
 	JsonErrMarshal   = errors.New("json.Marshal err")
@@ -56,6 +60,9 @@ var (
 	AppErrInvalidTxType   = New(20003, "invalid tx type")
 	AppErrInvalidTxField  = New(20004, "invalid tx field: ")
 	AppErrInvalidGasAsset = New(25005, "invalid gas asset")
+	AppErrAssetPaused     = New(25006, "asset is paused")
+	AppErrTxTypePaused    = New(25007, "tx type is paused")
+	AppErrUnauthorized    = New(29401, "unauthorized")
 	AppErrNotFound        = New(29404, "not found")
 	AppErrInternal        = New(29500, "internal server error")
 )
@@ -19,6 +19,9 @@ package types
 
 import (
 	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type NftInfo struct {
@@ -92,3 +95,29 @@ func ConstructNftInfo(
 		CollectionId:        collectionId,
 	}
 }
+
+// NftMetadata is the metadata document of a nft, the keccak256 hash of the document is the NftContentHash.
+type NftMetadata struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Image       string          `json:"image"`
+	Attributes  []*NftAttribute `json:"attributes"`
+}
+
+type NftAttribute struct {
+	TraitType string      `json:"trait_type"`
+	Value     interface{} `json:"value"`
+}
+
+func ParseNftMetadata(doc []byte) (metadata *NftMetadata, err error) {
+	err = json.Unmarshal(doc, &metadata)
+	if err != nil || metadata == nil {
+		return nil, JsonErrUnmarshal
+	}
+	return metadata, nil
+}
+
+// ComputeNftContentHash returns the hex encoded keccak256 hash of the metadata document.
+func ComputeNftContentHash(doc []byte) string {
+	return common.Bytes2Hex(crypto.Keccak256(doc))
+}
@@ -32,6 +32,11 @@ const (
 	AssetGovernanceContract = "AssetGovernanceContract"
 	Validators              = "Validators"
 
+	// Runtime controls which are changed by the admin api.
+	PausedTxTypes      = "PausedTxTypes"
+	OptionalBlockSizes = "OptionalBlockSizes"
+	SealBlockRequest   = "SealBlockRequest"
+
 	Governor       = "Governor"
 	ZnsPriceOracle = "ZnsPriceOracle"
 